@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"path/filepath"
@@ -14,7 +15,15 @@ import (
 	"github.com/Opulentia-Trading/Arbitrage/util"
 )
 
-const TickerLimit = 5
+const (
+	TickerLimit = 5
+	BlockLimit  = 3
+)
+
+// Implemented by the DEX handlers to size orders from human readable quantities
+type dexOrderBuilder interface {
+	BuildOrder(params *ethHandler.OrderParams) (models.Order, error)
+}
 
 func platformTest(platformName string, base string, quote string) {
 	platform, err := platform.GetPlatform(platformName)
@@ -72,32 +81,76 @@ func platformTest(platformName string, base string, quote string) {
 			Quantity: big.NewInt(200),
 			Deadline: time.Minute,
 		}
-	} else if platformInfo.Type == models.Decentralized {
-		amountIn := new(big.Int)
-		if _, ok := amountIn.SetString("1000000000000000", 10); !ok {
-			panic("failed to parse amountIn")
-		}
-
-		amountOut := new(big.Int)
-		if _, ok := amountOut.SetString("47100000000", 10); !ok { // Buy: 47100000000, Sell: 302199074434
-			panic("failed to parse amountOut")
+	} else if builder, ok := platform.(dexOrderBuilder); ok {
+		orderParams := ethHandler.OrderParams{
+			Base:        base,
+			Quote:       quote,
+			Action:      models.BuyLongSpot,
+			Quantity:    "0.05",
+			SlippageBps: 50,
+			Deadline:    time.Minute,
 		}
 
-		testOrder = models.Order{
-			Exchange:         platformInfo,
-			Base:             base,
-			Quote:            quote,
-			Action:           models.BuyLongSpot,
-			LiqPoolAmountIn:  amountIn,
-			LiqPoolAmountOut: amountOut,
-			Deadline:         time.Minute,
+		testOrder, err = builder.BuildOrder(&orderParams)
+		if err != nil {
+			panic(err)
 		}
+		fmt.Println("\n+--------- Build Order ---------+")
+		fmt.Println(util.PrettyPrint(testOrder))
 	}
 
 	fmt.Println("\n+--------- Execute Order ---------+")
 	platform.ExecuteOrder(testOrder)
 }
 
+// Implemented by the DEX handlers through their embedded EthHandler
+type blockFollower interface {
+	ForEachBlock(ctx context.Context, fn func(event *ethHandler.BlockEvent) error) error
+	FetchTickerInfoAt(snapshot *ethHandler.ChainSnapshot, base string, quote string) (models.TickerInfo, error)
+}
+
+// Re-evaluates the ticker of a pair once per new block
+func blockLoopTest(platformName string, base string, quote string) {
+	platform, err := platform.GetPlatform(platformName)
+	if err != nil {
+		panic(err)
+	}
+
+	follower, ok := platform.(blockFollower)
+	if !ok {
+		fmt.Printf("%v does not follow blocks\n", platformName)
+		return
+	}
+
+	fmt.Printf("========== %v block loop test ==========\n", platformName)
+	fmt.Printf("limit=%v\n", BlockLimit)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks := 0
+	err = follower.ForEachBlock(ctx, func(event *ethHandler.BlockEvent) error {
+		if event.Reorg != nil {
+			fmt.Printf("reorg: %v blocks removed after block %v\n", event.Reorg.Depth(), event.Reorg.ForkPoint.Number)
+		}
+
+		tickerInfo, err := follower.FetchTickerInfoAt(event.Snapshot(ctx), base, quote)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("block %v (%v): %v %v\n", event.Number(), event.Header.Hash(), tickerInfo.Symbol, tickerInfo.Price)
+
+		blocks++
+		if blocks >= BlockLimit {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
 func main() {
 	// Load env variables
 	dirname, err := util.CurDirname()
@@ -113,6 +166,9 @@ func main() {
 	for _, platformName := range platformNames {
 		platformTest(platformName, "LINK", "ETH")
 		fmt.Print("\n\n\n")
+
+		blockLoopTest(platformName, "LINK", "ETH")
+		fmt.Print("\n\n\n")
 	}
 
 	fmt.Println("========== eth gas estimation ==========")
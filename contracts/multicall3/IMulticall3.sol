@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+// Interface of Multicall3 (https://github.com/mds1/multicall), deployed at
+// 0xcA11bde05977b3631167028862bE2a173976CA11 on most EVM chains
+
+pragma solidity ^0.8.0;
+
+interface IMulticall3 {
+    struct Call3 {
+        address target;
+        bool allowFailure;
+        bytes callData;
+    }
+
+    struct Result {
+        bool success;
+        bytes returnData;
+    }
+
+    /// @notice Aggregate calls, ensuring each returns success if required
+    /// @param calls An array of Call3 structs
+    /// @return returnData An array of Result structs
+    function aggregate3(Call3[] calldata calls) external payable returns (Result[] memory returnData);
+
+    /// @notice Returns the block number
+    function getBlockNumber() external view returns (uint256 blockNumber);
+
+    /// @notice Returns the block hash for the given block number
+    /// @param blockNumber The block number
+    function getBlockHash(uint256 blockNumber) external view returns (bytes32 blockHash);
+
+    /// @notice Returns the (ETH) balance of a given address
+    function getEthBalance(address addr) external view returns (uint256 balance);
+}
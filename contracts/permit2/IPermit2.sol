@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+// Allowance transfer interface of Uniswap Permit2 (https://github.com/Uniswap/permit2), deployed at
+// 0x000000000022D473030F116dDEE9F6B43aC78BA3 on most EVM chains
+
+pragma solidity ^0.8.0;
+
+interface IAllowanceTransfer {
+    /// @notice Emits an event when the owner successfully sets permissions on a token for the spender.
+    event Approval(
+        address indexed owner, address indexed token, address indexed spender, uint160 amount, uint48 expiration
+    );
+
+    /// @notice Emits an event when the owner successfully sets permissions using a permit signature on a token for the spender.
+    event Permit(
+        address indexed owner,
+        address indexed token,
+        address indexed spender,
+        uint160 amount,
+        uint48 expiration,
+        uint48 nonce
+    );
+
+    /// @notice Emits an event when the owner sets the allowance back to 0 with the lockdown function.
+    event Lockdown(address indexed owner, address token, address spender);
+
+    /// @notice Emits an event when the owner invalidates nonces.
+    event NonceInvalidation(
+        address indexed owner, address indexed token, address indexed spender, uint48 newNonce, uint48 oldNonce
+    );
+
+    /// @notice The permit data for a token
+    struct PermitDetails {
+        // ERC20 token address
+        address token;
+        // the maximum amount allowed to spend
+        uint160 amount;
+        // timestamp at which a spender's token allowances become invalid
+        uint48 expiration;
+        // an incrementing value indexed per owner,token,and spender for each signature
+        uint48 nonce;
+    }
+
+    /// @notice The permit message signed for a single token allowance
+    struct PermitSingle {
+        // the permit data for a single token alownce
+        PermitDetails details;
+        // address permissioned on the allowed tokens
+        address spender;
+        // deadline on the permit signature
+        uint256 sigDeadline;
+    }
+
+    /// @notice The permit message signed for multiple token allowances
+    struct PermitBatch {
+        // the permit data for multiple token allowances
+        PermitDetails[] details;
+        // address permissioned on the allowed tokens
+        address spender;
+        // deadline on the permit signature
+        uint256 sigDeadline;
+    }
+
+    /// @notice A token spender pair.
+    struct TokenSpenderPair {
+        // the token the spender is approved
+        address token;
+        // the spender address
+        address spender;
+    }
+
+    /// @notice Returns the domain separator for the current chain.
+    function DOMAIN_SEPARATOR() external view returns (bytes32);
+
+    /// @notice A mapping from owner address to token address to spender address to PackedAllowance struct, which contains details and conditions of the approval.
+    function allowance(address user, address token, address spender)
+        external
+        view
+        returns (uint160 amount, uint48 expiration, uint48 nonce);
+
+    /// @notice Approves the spender to use up to amount of the specified token up until the expiration
+    function approve(address token, address spender, uint160 amount, uint48 expiration) external;
+
+    /// @notice Permit a spender to a given amount of the owners token via the owner's EIP-712 signature
+    function permit(address owner, PermitSingle memory permitSingle, bytes calldata signature) external;
+
+    /// @notice Permit a spender to the signed amounts of the owners tokens via the owner's EIP-712 signature
+    function permit(address owner, PermitBatch memory permitBatch, bytes calldata signature) external;
+
+    /// @notice Transfer approved tokens from one address to another
+    function transferFrom(address from, address to, uint160 amount, address token) external;
+
+    /// @notice Enables performing a "lockdown" of the sender's Permit2 identity by batch revoking approvals
+    function lockdown(TokenSpenderPair[] calldata approvals) external;
+
+    /// @notice Invalidate nonces for a given (token, spender) pair
+    function invalidateNonces(address token, address spender, uint48 newNonce) external;
+}
@@ -0,0 +1,222 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package uniswapUniversalRouter
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// UniswapUniversalRouterMetaData contains all meta data concerning the UniswapUniversalRouter contract.
+var UniswapUniversalRouterMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"commands\",\"type\":\"bytes\"},{\"internalType\":\"bytes[]\",\"name\":\"inputs\",\"type\":\"bytes[]\"},{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"}],\"name\":\"execute\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"commands\",\"type\":\"bytes\"},{\"internalType\":\"bytes[]\",\"name\":\"inputs\",\"type\":\"bytes[]\"}],\"name\":\"execute\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
+}
+
+// UniswapUniversalRouterABI is the input ABI used to generate the binding from.
+// Deprecated: Use UniswapUniversalRouterMetaData.ABI instead.
+var UniswapUniversalRouterABI = UniswapUniversalRouterMetaData.ABI
+
+// UniswapUniversalRouter is an auto generated Go binding around an Ethereum contract.
+type UniswapUniversalRouter struct {
+	UniswapUniversalRouterCaller     // Read-only binding to the contract
+	UniswapUniversalRouterTransactor // Write-only binding to the contract
+	UniswapUniversalRouterFilterer   // Log filterer for contract events
+}
+
+// UniswapUniversalRouterCaller is an auto generated read-only Go binding around an Ethereum contract.
+type UniswapUniversalRouterCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// UniswapUniversalRouterTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type UniswapUniversalRouterTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// UniswapUniversalRouterFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type UniswapUniversalRouterFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// UniswapUniversalRouterSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type UniswapUniversalRouterSession struct {
+	Contract     *UniswapUniversalRouter // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts           // Call options to use throughout this session
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// UniswapUniversalRouterCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type UniswapUniversalRouterCallerSession struct {
+	Contract *UniswapUniversalRouterCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                 // Call options to use throughout this session
+}
+
+// UniswapUniversalRouterTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type UniswapUniversalRouterTransactorSession struct {
+	Contract     *UniswapUniversalRouterTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                 // Transaction auth options to use throughout this session
+}
+
+// UniswapUniversalRouterRaw is an auto generated low-level Go binding around an Ethereum contract.
+type UniswapUniversalRouterRaw struct {
+	Contract *UniswapUniversalRouter // Generic contract binding to access the raw methods on
+}
+
+// UniswapUniversalRouterCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type UniswapUniversalRouterCallerRaw struct {
+	Contract *UniswapUniversalRouterCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// UniswapUniversalRouterTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type UniswapUniversalRouterTransactorRaw struct {
+	Contract *UniswapUniversalRouterTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewUniswapUniversalRouter creates a new instance of UniswapUniversalRouter, bound to a specific deployed contract.
+func NewUniswapUniversalRouter(address common.Address, backend bind.ContractBackend) (*UniswapUniversalRouter, error) {
+	contract, err := bindUniswapUniversalRouter(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapUniversalRouter{UniswapUniversalRouterCaller: UniswapUniversalRouterCaller{contract: contract}, UniswapUniversalRouterTransactor: UniswapUniversalRouterTransactor{contract: contract}, UniswapUniversalRouterFilterer: UniswapUniversalRouterFilterer{contract: contract}}, nil
+}
+
+// NewUniswapUniversalRouterCaller creates a new read-only instance of UniswapUniversalRouter, bound to a specific deployed contract.
+func NewUniswapUniversalRouterCaller(address common.Address, caller bind.ContractCaller) (*UniswapUniversalRouterCaller, error) {
+	contract, err := bindUniswapUniversalRouter(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapUniversalRouterCaller{contract: contract}, nil
+}
+
+// NewUniswapUniversalRouterTransactor creates a new write-only instance of UniswapUniversalRouter, bound to a specific deployed contract.
+func NewUniswapUniversalRouterTransactor(address common.Address, transactor bind.ContractTransactor) (*UniswapUniversalRouterTransactor, error) {
+	contract, err := bindUniswapUniversalRouter(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapUniversalRouterTransactor{contract: contract}, nil
+}
+
+// NewUniswapUniversalRouterFilterer creates a new log filterer instance of UniswapUniversalRouter, bound to a specific deployed contract.
+func NewUniswapUniversalRouterFilterer(address common.Address, filterer bind.ContractFilterer) (*UniswapUniversalRouterFilterer, error) {
+	contract, err := bindUniswapUniversalRouter(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapUniversalRouterFilterer{contract: contract}, nil
+}
+
+// bindUniswapUniversalRouter binds a generic wrapper to an already deployed contract.
+func bindUniswapUniversalRouter(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(UniswapUniversalRouterABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_UniswapUniversalRouter *UniswapUniversalRouterRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _UniswapUniversalRouter.Contract.UniswapUniversalRouterCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_UniswapUniversalRouter *UniswapUniversalRouterRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.Contract.UniswapUniversalRouterTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_UniswapUniversalRouter *UniswapUniversalRouterRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.Contract.UniswapUniversalRouterTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_UniswapUniversalRouter *UniswapUniversalRouterCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _UniswapUniversalRouter.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_UniswapUniversalRouter *UniswapUniversalRouterTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_UniswapUniversalRouter *UniswapUniversalRouterTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.Contract.contract.Transact(opts, method, params...)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x3593564c.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs, uint256 deadline) payable returns()
+func (_UniswapUniversalRouter *UniswapUniversalRouterTransactor) Execute(opts *bind.TransactOpts, commands []byte, inputs [][]byte, deadline *big.Int) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.contract.Transact(opts, "execute", commands, inputs, deadline)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x3593564c.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs, uint256 deadline) payable returns()
+func (_UniswapUniversalRouter *UniswapUniversalRouterSession) Execute(commands []byte, inputs [][]byte, deadline *big.Int) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.Contract.Execute(&_UniswapUniversalRouter.TransactOpts, commands, inputs, deadline)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x3593564c.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs, uint256 deadline) payable returns()
+func (_UniswapUniversalRouter *UniswapUniversalRouterTransactorSession) Execute(commands []byte, inputs [][]byte, deadline *big.Int) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.Contract.Execute(&_UniswapUniversalRouter.TransactOpts, commands, inputs, deadline)
+}
+
+// Execute0 is a paid mutator transaction binding the contract method 0x24856bc3.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs) payable returns()
+func (_UniswapUniversalRouter *UniswapUniversalRouterTransactor) Execute0(opts *bind.TransactOpts, commands []byte, inputs [][]byte) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.contract.Transact(opts, "execute0", commands, inputs)
+}
+
+// Execute0 is a paid mutator transaction binding the contract method 0x24856bc3.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs) payable returns()
+func (_UniswapUniversalRouter *UniswapUniversalRouterSession) Execute0(commands []byte, inputs [][]byte) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.Contract.Execute0(&_UniswapUniversalRouter.TransactOpts, commands, inputs)
+}
+
+// Execute0 is a paid mutator transaction binding the contract method 0x24856bc3.
+//
+// Solidity: function execute(bytes commands, bytes[] inputs) payable returns()
+func (_UniswapUniversalRouter *UniswapUniversalRouterTransactorSession) Execute0(commands []byte, inputs [][]byte) (*types.Transaction, error) {
+	return _UniswapUniversalRouter.Contract.Execute0(&_UniswapUniversalRouter.TransactOpts, commands, inputs)
+}
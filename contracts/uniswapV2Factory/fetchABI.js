@@ -0,0 +1,21 @@
+// Script to fetch the UniswapV2Factory ABI from npm and save it to a file
+
+const path = require('path');
+const fs = require('fs').promises;
+const {abi: IUniswapV2FactoryABI} = require("@uniswap/v2-core/build/IUniswapV2Factory.json");
+
+const postScriptMsg = `\nIMPORTANT: The next step is to convert the generated ABI into an importable Go file
+This can be automated into the script in the future
+Run the following command after the ABI is saved:
+    abigen --abi=uniswapV2Factory.abi --pkg=uniswapV2Factory --out=uniswapV2Factory.go`;
+
+(async () => {
+    try {
+        const outputFilename = 'uniswapV2Factory.abi'
+        await fs.writeFile(path.join(__dirname, outputFilename), JSON.stringify(IUniswapV2FactoryABI));
+        console.log(`Success: ABI saved to '${outputFilename}'`)
+        console.log(postScriptMsg)
+    } catch (err) {
+        console.error(err)
+    }
+})();
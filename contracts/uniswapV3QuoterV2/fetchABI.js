@@ -0,0 +1,21 @@
+// Script to fetch the UniswapV3QuoterV2 ABI from npm and save it to a file
+
+const path = require('path');
+const fs = require('fs').promises;
+const {abi: QuoterV2ABI} = require("@uniswap/v3-periphery/artifacts/contracts/lens/QuoterV2.sol/QuoterV2.json");
+
+const postScriptMsg = `\nIMPORTANT: The next step is to convert the generated ABI into an importable Go file
+This can be automated into the script in the future
+Run the following command after the ABI is saved:
+    abigen --abi=uniswapV3QuoterV2.abi --pkg=uniswapV3QuoterV2 --out=uniswapV3QuoterV2.go`;
+
+(async () => {
+    try {
+        const outputFilename = 'uniswapV3QuoterV2.abi'
+        await fs.writeFile(path.join(__dirname, outputFilename), JSON.stringify(QuoterV2ABI));
+        console.log(`Success: ABI saved to '${outputFilename}'`)
+        console.log(postScriptMsg)
+    } catch (err) {
+        console.error(err)
+    }
+})();
@@ -0,0 +1,21 @@
+// Script to fetch the UniswapV3SwapRouter ABI from npm and save it to a file
+
+const path = require('path');
+const fs = require('fs').promises;
+const {abi: SwapRouterABI} = require("@uniswap/v3-periphery/artifacts/contracts/SwapRouter.sol/SwapRouter.json");
+
+const postScriptMsg = `\nIMPORTANT: The next step is to convert the generated ABI into an importable Go file
+This can be automated into the script in the future
+Run the following command after the ABI is saved:
+    abigen --abi=uniswapV3SwapRouter.abi --pkg=uniswapV3SwapRouter --out=uniswapV3SwapRouter.go`;
+
+(async () => {
+    try {
+        const outputFilename = 'uniswapV3SwapRouter.abi'
+        await fs.writeFile(path.join(__dirname, outputFilename), JSON.stringify(SwapRouterABI));
+        console.log(`Success: ABI saved to '${outputFilename}'`)
+        console.log(postScriptMsg)
+    } catch (err) {
+        console.error(err)
+    }
+})();
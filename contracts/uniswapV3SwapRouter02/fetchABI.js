@@ -0,0 +1,21 @@
+// Script to fetch the UniswapV3SwapRouter02 ABI from npm and save it to a file
+
+const path = require('path');
+const fs = require('fs').promises;
+const {abi: SwapRouter02ABI} = require("@uniswap/swap-router-contracts/artifacts/contracts/SwapRouter02.sol/SwapRouter02.json");
+
+const postScriptMsg = `\nIMPORTANT: The next step is to convert the generated ABI into an importable Go file
+This can be automated into the script in the future
+Run the following command after the ABI is saved:
+    abigen --abi=uniswapV3SwapRouter02.abi --pkg=uniswapV3SwapRouter02 --out=uniswapV3SwapRouter02.go`;
+
+(async () => {
+    try {
+        const outputFilename = 'uniswapV3SwapRouter02.abi'
+        await fs.writeFile(path.join(__dirname, outputFilename), JSON.stringify(SwapRouter02ABI));
+        console.log(`Success: ABI saved to '${outputFilename}'`)
+        console.log(postScriptMsg)
+    } catch (err) {
+        console.error(err)
+    }
+})();
@@ -0,0 +1,710 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package uniswapV3SwapRouter02
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// IV3SwapRouterExactInputParams is an auto generated low-level Go binding around an user-defined struct.
+type IV3SwapRouterExactInputParams struct {
+	Path             []byte
+	Recipient        common.Address
+	AmountIn         *big.Int
+	AmountOutMinimum *big.Int
+}
+
+// IV3SwapRouterExactInputSingleParams is an auto generated low-level Go binding around an user-defined struct.
+type IV3SwapRouterExactInputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Fee               *big.Int
+	Recipient         common.Address
+	AmountIn          *big.Int
+	AmountOutMinimum  *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// IV3SwapRouterExactOutputParams is an auto generated low-level Go binding around an user-defined struct.
+type IV3SwapRouterExactOutputParams struct {
+	Path            []byte
+	Recipient       common.Address
+	AmountOut       *big.Int
+	AmountInMaximum *big.Int
+}
+
+// IV3SwapRouterExactOutputSingleParams is an auto generated low-level Go binding around an user-defined struct.
+type IV3SwapRouterExactOutputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Fee               *big.Int
+	Recipient         common.Address
+	AmountOut         *big.Int
+	AmountInMaximum   *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// UniswapV3SwapRouter02MetaData contains all meta data concerning the UniswapV3SwapRouter02 contract.
+var UniswapV3SwapRouter02MetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"WETH9\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structIV3SwapRouter.ExactInputParams\",\"name\":\"params\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"bytes\",\"name\":\"path\",\"type\":\"bytes\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amountIn\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amountOutMinimum\",\"type\":\"uint256\"}]}],\"name\":\"exactInput\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"amountOut\",\"type\":\"uint256\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structIV3SwapRouter.ExactInputSingleParams\",\"name\":\"params\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"tokenIn\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"tokenOut\",\"type\":\"address\"},{\"internalType\":\"uint24\",\"name\":\"fee\",\"type\":\"uint24\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amountIn\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amountOutMinimum\",\"type\":\"uint256\"},{\"internalType\":\"uint160\",\"name\":\"sqrtPriceLimitX96\",\"type\":\"uint160\"}]}],\"name\":\"exactInputSingle\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"amountOut\",\"type\":\"uint256\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structIV3SwapRouter.ExactOutputParams\",\"name\":\"params\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"bytes\",\"name\":\"path\",\"type\":\"bytes\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amountOut\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amountInMaximum\",\"type\":\"uint256\"}]}],\"name\":\"exactOutput\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"amountIn\",\"type\":\"uint256\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structIV3SwapRouter.ExactOutputSingleParams\",\"name\":\"params\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"tokenIn\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"tokenOut\",\"type\":\"address\"},{\"internalType\":\"uint24\",\"name\":\"fee\",\"type\":\"uint24\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amountOut\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amountInMaximum\",\"type\":\"uint256\"},{\"internalType\":\"uint160\",\"name\":\"sqrtPriceLimitX96\",\"type\":\"uint160\"}]}],\"name\":\"exactOutputSingle\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"amountIn\",\"type\":\"uint256\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"factory\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"factoryV2\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"previousBlockhash\",\"type\":\"bytes32\"},{\"internalType\":\"bytes[]\",\"name\":\"data\",\"type\":\"bytes[]\"}],\"name\":\"multicall\",\"outputs\":[{\"internalType\":\"bytes[]\",\"name\":\"\",\"type\":\"bytes[]\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"},{\"internalType\":\"bytes[]\",\"name\":\"data\",\"type\":\"bytes[]\"}],\"name\":\"multicall\",\"outputs\":[{\"internalType\":\"bytes[]\",\"name\":\"\",\"type\":\"bytes[]\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes[]\",\"name\":\"data\",\"type\":\"bytes[]\"}],\"name\":\"multicall\",\"outputs\":[{\"internalType\":\"bytes[]\",\"name\":\"results\",\"type\":\"bytes[]\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"refundETH\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"selfPermit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"expiry\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"selfPermitAllowed\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"expiry\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"selfPermitAllowedIfNecessary\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"},{\"internalType\":\"uint8\",\"name\":\"v\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"r\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"selfPermitIfNecessary\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amountMinimum\",\"type\":\"uint256\"}],\"name\":\"sweepToken\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amountMinimum\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"}],\"name\":\"sweepToken\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"int256\",\"name\":\"amount0Delta\",\"type\":\"int256\"},{\"internalType\":\"int256\",\"name\":\"amount1Delta\",\"type\":\"int256\"},{\"internalType\":\"bytes\",\"name\":\"_data\",\"type\":\"bytes\"}],\"name\":\"uniswapV3SwapCallback\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amountMinimum\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"}],\"name\":\"unwrapWETH9\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amountMinimum\",\"type\":\"uint256\"}],\"name\":\"unwrapWETH9\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"wrapETH\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+}
+
+// UniswapV3SwapRouter02ABI is the input ABI used to generate the binding from.
+// Deprecated: Use UniswapV3SwapRouter02MetaData.ABI instead.
+var UniswapV3SwapRouter02ABI = UniswapV3SwapRouter02MetaData.ABI
+
+// UniswapV3SwapRouter02 is an auto generated Go binding around an Ethereum contract.
+type UniswapV3SwapRouter02 struct {
+	UniswapV3SwapRouter02Caller     // Read-only binding to the contract
+	UniswapV3SwapRouter02Transactor // Write-only binding to the contract
+	UniswapV3SwapRouter02Filterer   // Log filterer for contract events
+}
+
+// UniswapV3SwapRouter02Caller is an auto generated read-only Go binding around an Ethereum contract.
+type UniswapV3SwapRouter02Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// UniswapV3SwapRouter02Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type UniswapV3SwapRouter02Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// UniswapV3SwapRouter02Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type UniswapV3SwapRouter02Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// UniswapV3SwapRouter02Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type UniswapV3SwapRouter02Session struct {
+	Contract     *UniswapV3SwapRouter02 // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts          // Call options to use throughout this session
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// UniswapV3SwapRouter02CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type UniswapV3SwapRouter02CallerSession struct {
+	Contract *UniswapV3SwapRouter02Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                // Call options to use throughout this session
+}
+
+// UniswapV3SwapRouter02TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type UniswapV3SwapRouter02TransactorSession struct {
+	Contract     *UniswapV3SwapRouter02Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                // Transaction auth options to use throughout this session
+}
+
+// UniswapV3SwapRouter02Raw is an auto generated low-level Go binding around an Ethereum contract.
+type UniswapV3SwapRouter02Raw struct {
+	Contract *UniswapV3SwapRouter02 // Generic contract binding to access the raw methods on
+}
+
+// UniswapV3SwapRouter02CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type UniswapV3SwapRouter02CallerRaw struct {
+	Contract *UniswapV3SwapRouter02Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// UniswapV3SwapRouter02TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type UniswapV3SwapRouter02TransactorRaw struct {
+	Contract *UniswapV3SwapRouter02Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewUniswapV3SwapRouter02 creates a new instance of UniswapV3SwapRouter02, bound to a specific deployed contract.
+func NewUniswapV3SwapRouter02(address common.Address, backend bind.ContractBackend) (*UniswapV3SwapRouter02, error) {
+	contract, err := bindUniswapV3SwapRouter02(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapV3SwapRouter02{UniswapV3SwapRouter02Caller: UniswapV3SwapRouter02Caller{contract: contract}, UniswapV3SwapRouter02Transactor: UniswapV3SwapRouter02Transactor{contract: contract}, UniswapV3SwapRouter02Filterer: UniswapV3SwapRouter02Filterer{contract: contract}}, nil
+}
+
+// NewUniswapV3SwapRouter02Caller creates a new read-only instance of UniswapV3SwapRouter02, bound to a specific deployed contract.
+func NewUniswapV3SwapRouter02Caller(address common.Address, caller bind.ContractCaller) (*UniswapV3SwapRouter02Caller, error) {
+	contract, err := bindUniswapV3SwapRouter02(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapV3SwapRouter02Caller{contract: contract}, nil
+}
+
+// NewUniswapV3SwapRouter02Transactor creates a new write-only instance of UniswapV3SwapRouter02, bound to a specific deployed contract.
+func NewUniswapV3SwapRouter02Transactor(address common.Address, transactor bind.ContractTransactor) (*UniswapV3SwapRouter02Transactor, error) {
+	contract, err := bindUniswapV3SwapRouter02(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapV3SwapRouter02Transactor{contract: contract}, nil
+}
+
+// NewUniswapV3SwapRouter02Filterer creates a new log filterer instance of UniswapV3SwapRouter02, bound to a specific deployed contract.
+func NewUniswapV3SwapRouter02Filterer(address common.Address, filterer bind.ContractFilterer) (*UniswapV3SwapRouter02Filterer, error) {
+	contract, err := bindUniswapV3SwapRouter02(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapV3SwapRouter02Filterer{contract: contract}, nil
+}
+
+// bindUniswapV3SwapRouter02 binds a generic wrapper to an already deployed contract.
+func bindUniswapV3SwapRouter02(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(UniswapV3SwapRouter02ABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _UniswapV3SwapRouter02.Contract.UniswapV3SwapRouter02Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.UniswapV3SwapRouter02Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.UniswapV3SwapRouter02Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _UniswapV3SwapRouter02.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.contract.Transact(opts, method, params...)
+}
+
+// WETH9 is a free data retrieval call binding the contract method 0x4aa4a4fc.
+//
+// Solidity: function WETH9() view returns(address)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Caller) WETH9(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _UniswapV3SwapRouter02.contract.Call(opts, &out, "WETH9")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// WETH9 is a free data retrieval call binding the contract method 0x4aa4a4fc.
+//
+// Solidity: function WETH9() view returns(address)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) WETH9() (common.Address, error) {
+	return _UniswapV3SwapRouter02.Contract.WETH9(&_UniswapV3SwapRouter02.CallOpts)
+}
+
+// WETH9 is a free data retrieval call binding the contract method 0x4aa4a4fc.
+//
+// Solidity: function WETH9() view returns(address)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02CallerSession) WETH9() (common.Address, error) {
+	return _UniswapV3SwapRouter02.Contract.WETH9(&_UniswapV3SwapRouter02.CallOpts)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Caller) Factory(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _UniswapV3SwapRouter02.contract.Call(opts, &out, "factory")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) Factory() (common.Address, error) {
+	return _UniswapV3SwapRouter02.Contract.Factory(&_UniswapV3SwapRouter02.CallOpts)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02CallerSession) Factory() (common.Address, error) {
+	return _UniswapV3SwapRouter02.Contract.Factory(&_UniswapV3SwapRouter02.CallOpts)
+}
+
+// FactoryV2 is a free data retrieval call binding the contract method 0x68e0d4e1.
+//
+// Solidity: function factoryV2() view returns(address)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Caller) FactoryV2(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _UniswapV3SwapRouter02.contract.Call(opts, &out, "factoryV2")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// FactoryV2 is a free data retrieval call binding the contract method 0x68e0d4e1.
+//
+// Solidity: function factoryV2() view returns(address)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) FactoryV2() (common.Address, error) {
+	return _UniswapV3SwapRouter02.Contract.FactoryV2(&_UniswapV3SwapRouter02.CallOpts)
+}
+
+// FactoryV2 is a free data retrieval call binding the contract method 0x68e0d4e1.
+//
+// Solidity: function factoryV2() view returns(address)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02CallerSession) FactoryV2() (common.Address, error) {
+	return _UniswapV3SwapRouter02.Contract.FactoryV2(&_UniswapV3SwapRouter02.CallOpts)
+}
+
+// ExactInput is a paid mutator transaction binding the contract method 0xb858183f.
+//
+// Solidity: function exactInput((bytes,address,uint256,uint256) params) payable returns(uint256 amountOut)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) ExactInput(opts *bind.TransactOpts, params IV3SwapRouterExactInputParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "exactInput", params)
+}
+
+// ExactInput is a paid mutator transaction binding the contract method 0xb858183f.
+//
+// Solidity: function exactInput((bytes,address,uint256,uint256) params) payable returns(uint256 amountOut)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) ExactInput(params IV3SwapRouterExactInputParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.ExactInput(&_UniswapV3SwapRouter02.TransactOpts, params)
+}
+
+// ExactInput is a paid mutator transaction binding the contract method 0xb858183f.
+//
+// Solidity: function exactInput((bytes,address,uint256,uint256) params) payable returns(uint256 amountOut)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) ExactInput(params IV3SwapRouterExactInputParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.ExactInput(&_UniswapV3SwapRouter02.TransactOpts, params)
+}
+
+// ExactInputSingle is a paid mutator transaction binding the contract method 0x04e45aaf.
+//
+// Solidity: function exactInputSingle((address,address,uint24,address,uint256,uint256,uint160) params) payable returns(uint256 amountOut)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) ExactInputSingle(opts *bind.TransactOpts, params IV3SwapRouterExactInputSingleParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "exactInputSingle", params)
+}
+
+// ExactInputSingle is a paid mutator transaction binding the contract method 0x04e45aaf.
+//
+// Solidity: function exactInputSingle((address,address,uint24,address,uint256,uint256,uint160) params) payable returns(uint256 amountOut)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) ExactInputSingle(params IV3SwapRouterExactInputSingleParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.ExactInputSingle(&_UniswapV3SwapRouter02.TransactOpts, params)
+}
+
+// ExactInputSingle is a paid mutator transaction binding the contract method 0x04e45aaf.
+//
+// Solidity: function exactInputSingle((address,address,uint24,address,uint256,uint256,uint160) params) payable returns(uint256 amountOut)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) ExactInputSingle(params IV3SwapRouterExactInputSingleParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.ExactInputSingle(&_UniswapV3SwapRouter02.TransactOpts, params)
+}
+
+// ExactOutput is a paid mutator transaction binding the contract method 0x09b81346.
+//
+// Solidity: function exactOutput((bytes,address,uint256,uint256) params) payable returns(uint256 amountIn)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) ExactOutput(opts *bind.TransactOpts, params IV3SwapRouterExactOutputParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "exactOutput", params)
+}
+
+// ExactOutput is a paid mutator transaction binding the contract method 0x09b81346.
+//
+// Solidity: function exactOutput((bytes,address,uint256,uint256) params) payable returns(uint256 amountIn)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) ExactOutput(params IV3SwapRouterExactOutputParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.ExactOutput(&_UniswapV3SwapRouter02.TransactOpts, params)
+}
+
+// ExactOutput is a paid mutator transaction binding the contract method 0x09b81346.
+//
+// Solidity: function exactOutput((bytes,address,uint256,uint256) params) payable returns(uint256 amountIn)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) ExactOutput(params IV3SwapRouterExactOutputParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.ExactOutput(&_UniswapV3SwapRouter02.TransactOpts, params)
+}
+
+// ExactOutputSingle is a paid mutator transaction binding the contract method 0x5023b4df.
+//
+// Solidity: function exactOutputSingle((address,address,uint24,address,uint256,uint256,uint160) params) payable returns(uint256 amountIn)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) ExactOutputSingle(opts *bind.TransactOpts, params IV3SwapRouterExactOutputSingleParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "exactOutputSingle", params)
+}
+
+// ExactOutputSingle is a paid mutator transaction binding the contract method 0x5023b4df.
+//
+// Solidity: function exactOutputSingle((address,address,uint24,address,uint256,uint256,uint160) params) payable returns(uint256 amountIn)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) ExactOutputSingle(params IV3SwapRouterExactOutputSingleParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.ExactOutputSingle(&_UniswapV3SwapRouter02.TransactOpts, params)
+}
+
+// ExactOutputSingle is a paid mutator transaction binding the contract method 0x5023b4df.
+//
+// Solidity: function exactOutputSingle((address,address,uint24,address,uint256,uint256,uint160) params) payable returns(uint256 amountIn)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) ExactOutputSingle(params IV3SwapRouterExactOutputSingleParams) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.ExactOutputSingle(&_UniswapV3SwapRouter02.TransactOpts, params)
+}
+
+// Multicall is a paid mutator transaction binding the contract method 0x1f0464d1.
+//
+// Solidity: function multicall(bytes32 previousBlockhash, bytes[] data) payable returns(bytes[])
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) Multicall(opts *bind.TransactOpts, previousBlockhash [32]byte, data [][]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "multicall", previousBlockhash, data)
+}
+
+// Multicall is a paid mutator transaction binding the contract method 0x1f0464d1.
+//
+// Solidity: function multicall(bytes32 previousBlockhash, bytes[] data) payable returns(bytes[])
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) Multicall(previousBlockhash [32]byte, data [][]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.Multicall(&_UniswapV3SwapRouter02.TransactOpts, previousBlockhash, data)
+}
+
+// Multicall is a paid mutator transaction binding the contract method 0x1f0464d1.
+//
+// Solidity: function multicall(bytes32 previousBlockhash, bytes[] data) payable returns(bytes[])
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) Multicall(previousBlockhash [32]byte, data [][]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.Multicall(&_UniswapV3SwapRouter02.TransactOpts, previousBlockhash, data)
+}
+
+// Multicall0 is a paid mutator transaction binding the contract method 0x5ae401dc.
+//
+// Solidity: function multicall(uint256 deadline, bytes[] data) payable returns(bytes[])
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) Multicall0(opts *bind.TransactOpts, deadline *big.Int, data [][]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "multicall0", deadline, data)
+}
+
+// Multicall0 is a paid mutator transaction binding the contract method 0x5ae401dc.
+//
+// Solidity: function multicall(uint256 deadline, bytes[] data) payable returns(bytes[])
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) Multicall0(deadline *big.Int, data [][]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.Multicall0(&_UniswapV3SwapRouter02.TransactOpts, deadline, data)
+}
+
+// Multicall0 is a paid mutator transaction binding the contract method 0x5ae401dc.
+//
+// Solidity: function multicall(uint256 deadline, bytes[] data) payable returns(bytes[])
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) Multicall0(deadline *big.Int, data [][]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.Multicall0(&_UniswapV3SwapRouter02.TransactOpts, deadline, data)
+}
+
+// Multicall1 is a paid mutator transaction binding the contract method 0xac9650d8.
+//
+// Solidity: function multicall(bytes[] data) payable returns(bytes[] results)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) Multicall1(opts *bind.TransactOpts, data [][]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "multicall1", data)
+}
+
+// Multicall1 is a paid mutator transaction binding the contract method 0xac9650d8.
+//
+// Solidity: function multicall(bytes[] data) payable returns(bytes[] results)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) Multicall1(data [][]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.Multicall1(&_UniswapV3SwapRouter02.TransactOpts, data)
+}
+
+// Multicall1 is a paid mutator transaction binding the contract method 0xac9650d8.
+//
+// Solidity: function multicall(bytes[] data) payable returns(bytes[] results)
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) Multicall1(data [][]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.Multicall1(&_UniswapV3SwapRouter02.TransactOpts, data)
+}
+
+// RefundETH is a paid mutator transaction binding the contract method 0x12210e8a.
+//
+// Solidity: function refundETH() payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) RefundETH(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "refundETH")
+}
+
+// RefundETH is a paid mutator transaction binding the contract method 0x12210e8a.
+//
+// Solidity: function refundETH() payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) RefundETH() (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.RefundETH(&_UniswapV3SwapRouter02.TransactOpts)
+}
+
+// RefundETH is a paid mutator transaction binding the contract method 0x12210e8a.
+//
+// Solidity: function refundETH() payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) RefundETH() (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.RefundETH(&_UniswapV3SwapRouter02.TransactOpts)
+}
+
+// SelfPermit is a paid mutator transaction binding the contract method 0xf3995c67.
+//
+// Solidity: function selfPermit(address token, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) SelfPermit(opts *bind.TransactOpts, token common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "selfPermit", token, value, deadline, v, r, s)
+}
+
+// SelfPermit is a paid mutator transaction binding the contract method 0xf3995c67.
+//
+// Solidity: function selfPermit(address token, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) SelfPermit(token common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SelfPermit(&_UniswapV3SwapRouter02.TransactOpts, token, value, deadline, v, r, s)
+}
+
+// SelfPermit is a paid mutator transaction binding the contract method 0xf3995c67.
+//
+// Solidity: function selfPermit(address token, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) SelfPermit(token common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SelfPermit(&_UniswapV3SwapRouter02.TransactOpts, token, value, deadline, v, r, s)
+}
+
+// SelfPermitAllowed is a paid mutator transaction binding the contract method 0x4659a494.
+//
+// Solidity: function selfPermitAllowed(address token, uint256 nonce, uint256 expiry, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) SelfPermitAllowed(opts *bind.TransactOpts, token common.Address, nonce *big.Int, expiry *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "selfPermitAllowed", token, nonce, expiry, v, r, s)
+}
+
+// SelfPermitAllowed is a paid mutator transaction binding the contract method 0x4659a494.
+//
+// Solidity: function selfPermitAllowed(address token, uint256 nonce, uint256 expiry, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) SelfPermitAllowed(token common.Address, nonce *big.Int, expiry *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SelfPermitAllowed(&_UniswapV3SwapRouter02.TransactOpts, token, nonce, expiry, v, r, s)
+}
+
+// SelfPermitAllowed is a paid mutator transaction binding the contract method 0x4659a494.
+//
+// Solidity: function selfPermitAllowed(address token, uint256 nonce, uint256 expiry, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) SelfPermitAllowed(token common.Address, nonce *big.Int, expiry *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SelfPermitAllowed(&_UniswapV3SwapRouter02.TransactOpts, token, nonce, expiry, v, r, s)
+}
+
+// SelfPermitAllowedIfNecessary is a paid mutator transaction binding the contract method 0xa4a78f0c.
+//
+// Solidity: function selfPermitAllowedIfNecessary(address token, uint256 nonce, uint256 expiry, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) SelfPermitAllowedIfNecessary(opts *bind.TransactOpts, token common.Address, nonce *big.Int, expiry *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "selfPermitAllowedIfNecessary", token, nonce, expiry, v, r, s)
+}
+
+// SelfPermitAllowedIfNecessary is a paid mutator transaction binding the contract method 0xa4a78f0c.
+//
+// Solidity: function selfPermitAllowedIfNecessary(address token, uint256 nonce, uint256 expiry, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) SelfPermitAllowedIfNecessary(token common.Address, nonce *big.Int, expiry *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SelfPermitAllowedIfNecessary(&_UniswapV3SwapRouter02.TransactOpts, token, nonce, expiry, v, r, s)
+}
+
+// SelfPermitAllowedIfNecessary is a paid mutator transaction binding the contract method 0xa4a78f0c.
+//
+// Solidity: function selfPermitAllowedIfNecessary(address token, uint256 nonce, uint256 expiry, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) SelfPermitAllowedIfNecessary(token common.Address, nonce *big.Int, expiry *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SelfPermitAllowedIfNecessary(&_UniswapV3SwapRouter02.TransactOpts, token, nonce, expiry, v, r, s)
+}
+
+// SelfPermitIfNecessary is a paid mutator transaction binding the contract method 0xc2e3140a.
+//
+// Solidity: function selfPermitIfNecessary(address token, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) SelfPermitIfNecessary(opts *bind.TransactOpts, token common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "selfPermitIfNecessary", token, value, deadline, v, r, s)
+}
+
+// SelfPermitIfNecessary is a paid mutator transaction binding the contract method 0xc2e3140a.
+//
+// Solidity: function selfPermitIfNecessary(address token, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) SelfPermitIfNecessary(token common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SelfPermitIfNecessary(&_UniswapV3SwapRouter02.TransactOpts, token, value, deadline, v, r, s)
+}
+
+// SelfPermitIfNecessary is a paid mutator transaction binding the contract method 0xc2e3140a.
+//
+// Solidity: function selfPermitIfNecessary(address token, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) SelfPermitIfNecessary(token common.Address, value *big.Int, deadline *big.Int, v uint8, r [32]byte, s [32]byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SelfPermitIfNecessary(&_UniswapV3SwapRouter02.TransactOpts, token, value, deadline, v, r, s)
+}
+
+// SweepToken is a paid mutator transaction binding the contract method 0xe90a182f.
+//
+// Solidity: function sweepToken(address token, uint256 amountMinimum) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) SweepToken(opts *bind.TransactOpts, token common.Address, amountMinimum *big.Int) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "sweepToken", token, amountMinimum)
+}
+
+// SweepToken is a paid mutator transaction binding the contract method 0xe90a182f.
+//
+// Solidity: function sweepToken(address token, uint256 amountMinimum) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) SweepToken(token common.Address, amountMinimum *big.Int) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SweepToken(&_UniswapV3SwapRouter02.TransactOpts, token, amountMinimum)
+}
+
+// SweepToken is a paid mutator transaction binding the contract method 0xe90a182f.
+//
+// Solidity: function sweepToken(address token, uint256 amountMinimum) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) SweepToken(token common.Address, amountMinimum *big.Int) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SweepToken(&_UniswapV3SwapRouter02.TransactOpts, token, amountMinimum)
+}
+
+// SweepToken0 is a paid mutator transaction binding the contract method 0xdf2ab5bb.
+//
+// Solidity: function sweepToken(address token, uint256 amountMinimum, address recipient) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) SweepToken0(opts *bind.TransactOpts, token common.Address, amountMinimum *big.Int, recipient common.Address) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "sweepToken0", token, amountMinimum, recipient)
+}
+
+// SweepToken0 is a paid mutator transaction binding the contract method 0xdf2ab5bb.
+//
+// Solidity: function sweepToken(address token, uint256 amountMinimum, address recipient) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) SweepToken0(token common.Address, amountMinimum *big.Int, recipient common.Address) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SweepToken0(&_UniswapV3SwapRouter02.TransactOpts, token, amountMinimum, recipient)
+}
+
+// SweepToken0 is a paid mutator transaction binding the contract method 0xdf2ab5bb.
+//
+// Solidity: function sweepToken(address token, uint256 amountMinimum, address recipient) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) SweepToken0(token common.Address, amountMinimum *big.Int, recipient common.Address) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.SweepToken0(&_UniswapV3SwapRouter02.TransactOpts, token, amountMinimum, recipient)
+}
+
+// UniswapV3SwapCallback is a paid mutator transaction binding the contract method 0xfa461e33.
+//
+// Solidity: function uniswapV3SwapCallback(int256 amount0Delta, int256 amount1Delta, bytes _data) returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) UniswapV3SwapCallback(opts *bind.TransactOpts, amount0Delta *big.Int, amount1Delta *big.Int, _data []byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "uniswapV3SwapCallback", amount0Delta, amount1Delta, _data)
+}
+
+// UniswapV3SwapCallback is a paid mutator transaction binding the contract method 0xfa461e33.
+//
+// Solidity: function uniswapV3SwapCallback(int256 amount0Delta, int256 amount1Delta, bytes _data) returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) UniswapV3SwapCallback(amount0Delta *big.Int, amount1Delta *big.Int, _data []byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.UniswapV3SwapCallback(&_UniswapV3SwapRouter02.TransactOpts, amount0Delta, amount1Delta, _data)
+}
+
+// UniswapV3SwapCallback is a paid mutator transaction binding the contract method 0xfa461e33.
+//
+// Solidity: function uniswapV3SwapCallback(int256 amount0Delta, int256 amount1Delta, bytes _data) returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) UniswapV3SwapCallback(amount0Delta *big.Int, amount1Delta *big.Int, _data []byte) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.UniswapV3SwapCallback(&_UniswapV3SwapRouter02.TransactOpts, amount0Delta, amount1Delta, _data)
+}
+
+// UnwrapWETH9 is a paid mutator transaction binding the contract method 0x49404b7c.
+//
+// Solidity: function unwrapWETH9(uint256 amountMinimum, address recipient) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) UnwrapWETH9(opts *bind.TransactOpts, amountMinimum *big.Int, recipient common.Address) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "unwrapWETH9", amountMinimum, recipient)
+}
+
+// UnwrapWETH9 is a paid mutator transaction binding the contract method 0x49404b7c.
+//
+// Solidity: function unwrapWETH9(uint256 amountMinimum, address recipient) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) UnwrapWETH9(amountMinimum *big.Int, recipient common.Address) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.UnwrapWETH9(&_UniswapV3SwapRouter02.TransactOpts, amountMinimum, recipient)
+}
+
+// UnwrapWETH9 is a paid mutator transaction binding the contract method 0x49404b7c.
+//
+// Solidity: function unwrapWETH9(uint256 amountMinimum, address recipient) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) UnwrapWETH9(amountMinimum *big.Int, recipient common.Address) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.UnwrapWETH9(&_UniswapV3SwapRouter02.TransactOpts, amountMinimum, recipient)
+}
+
+// UnwrapWETH90 is a paid mutator transaction binding the contract method 0x49616997.
+//
+// Solidity: function unwrapWETH9(uint256 amountMinimum) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) UnwrapWETH90(opts *bind.TransactOpts, amountMinimum *big.Int) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "unwrapWETH90", amountMinimum)
+}
+
+// UnwrapWETH90 is a paid mutator transaction binding the contract method 0x49616997.
+//
+// Solidity: function unwrapWETH9(uint256 amountMinimum) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) UnwrapWETH90(amountMinimum *big.Int) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.UnwrapWETH90(&_UniswapV3SwapRouter02.TransactOpts, amountMinimum)
+}
+
+// UnwrapWETH90 is a paid mutator transaction binding the contract method 0x49616997.
+//
+// Solidity: function unwrapWETH9(uint256 amountMinimum) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) UnwrapWETH90(amountMinimum *big.Int) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.UnwrapWETH90(&_UniswapV3SwapRouter02.TransactOpts, amountMinimum)
+}
+
+// WrapETH is a paid mutator transaction binding the contract method 0x1c58db4f.
+//
+// Solidity: function wrapETH(uint256 value) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) WrapETH(opts *bind.TransactOpts, value *big.Int) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.Transact(opts, "wrapETH", value)
+}
+
+// WrapETH is a paid mutator transaction binding the contract method 0x1c58db4f.
+//
+// Solidity: function wrapETH(uint256 value) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) WrapETH(value *big.Int) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.WrapETH(&_UniswapV3SwapRouter02.TransactOpts, value)
+}
+
+// WrapETH is a paid mutator transaction binding the contract method 0x1c58db4f.
+//
+// Solidity: function wrapETH(uint256 value) payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) WrapETH(value *big.Int) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.WrapETH(&_UniswapV3SwapRouter02.TransactOpts, value)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Transactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02Session) Receive() (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.Receive(&_UniswapV3SwapRouter02.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_UniswapV3SwapRouter02 *UniswapV3SwapRouter02TransactorSession) Receive() (*types.Transaction, error) {
+	return _UniswapV3SwapRouter02.Contract.Receive(&_UniswapV3SwapRouter02.TransactOpts)
+}
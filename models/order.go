@@ -5,6 +5,20 @@ import (
 	"time"
 )
 
+// Determines which amount of a DEX swap is fixed
+type SwapKind uint
+
+const (
+	ExactInput  SwapKind = iota // spend LiqPoolAmountIn, receive at least LiqPoolAmountOut
+	ExactOutput                 // receive LiqPoolAmountOut, spend at most LiqPoolAmountIn
+)
+
+func (k SwapKind) String() string {
+	return [...]string{
+		"ExactInput",
+		"ExactOutput"}[k]
+}
+
 type Order struct {
 	Exchange         *Exchange
 	Base             string
@@ -12,6 +26,7 @@ type Order struct {
 	Action           Action
 	Price            *big.Rat
 	Quantity         *big.Int
+	SwapKind         SwapKind
 	LiqPoolAmountIn  *big.Int // in wei
 	LiqPoolAmountOut *big.Int // in wei
 	Deadline         time.Duration
@@ -10,4 +10,5 @@ type TickerInfo struct {
 	MakerComission string
 	TakerComission string
 	Timestamp      time.Time
+	BlockNumber    uint64 // block the price was read at, zero for centralized exchanges
 }
@@ -0,0 +1,284 @@
+package assetRegistry
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Canonical identifier of an asset shared by every venue, e.g. "BTC" or "WETH".
+// Wrapped and bridged variants keep their own id and are linked to their
+// underlying asset through an explicit equivalence rule.
+type AssetId string
+
+type EquivalenceKind uint
+
+const (
+	Wrapped   EquivalenceKind = iota // redeemable 1:1 on-chain by a contract (WETH/ETH)
+	Custodial                        // backed 1:1 by an off-chain custodian (WBTC/BTC)
+	Bridged                          // minted by a bridge from another chain (USDC.e/USDC)
+)
+
+func (k EquivalenceKind) String() string {
+	return [...]string{
+		"Wrapped",
+		"Custodial",
+		"Bridged"}[k]
+}
+
+type Equivalence struct {
+	Variant    AssetId
+	Underlying AssetId
+	Kind       EquivalenceKind
+}
+
+type registry struct {
+	mu sync.RWMutex
+
+	// venue|venueSymbol => asset
+	venueSymbols map[string]AssetId
+	// venue|asset => venueSymbol
+	venueAssets map[string]string
+	// chainId|address => asset
+	tokenAssets map[string]AssetId
+	// chainId|asset => address
+	chainTokens map[string]string
+	// variant => equivalence
+	equivalences map[AssetId]*Equivalence
+}
+
+// TODO: Parse from json or config file
+var equivalences = []*Equivalence{
+	{Variant: "WETH", Underlying: "ETH", Kind: Wrapped},
+	{Variant: "WBNB", Underlying: "BNB", Kind: Wrapped},
+	{Variant: "WMATIC", Underlying: "MATIC", Kind: Wrapped},
+	{Variant: "WBTC", Underlying: "BTC", Kind: Custodial},
+	{Variant: "USDC.e", Underlying: "USDC", Kind: Bridged},
+	{Variant: "USDT.e", Underlying: "USDT", Kind: Bridged},
+}
+
+// Venue tickers which differ from the canonical asset id.
+// The first symbol listed for an asset is the one used in requests to the venue.
+// TODO: Parse from json or config file
+var venueSymbols = map[string][]struct {
+	Symbol string
+	Asset  AssetId
+}{
+	// https://support.kraken.com/hc/en-us/articles/360001185506
+	"kraken": {
+		{"XBT", "BTC"},
+		{"XXBT", "BTC"},
+		{"XDG", "DOGE"},
+		{"XXDG", "DOGE"},
+		{"XETH", "ETH"},
+		{"XETC", "ETC"},
+		{"XLTC", "LTC"},
+		{"XMLN", "MLN"},
+		{"XREP", "REP"},
+		{"XXLM", "XLM"},
+		{"XXMR", "XMR"},
+		{"XXRP", "XRP"},
+		{"XZEC", "ZEC"},
+		{"ZAUD", "AUD"},
+		{"ZCAD", "CAD"},
+		{"ZEUR", "EUR"},
+		{"ZGBP", "GBP"},
+		{"ZJPY", "JPY"},
+		{"ZUSD", "USD"},
+	},
+	"coinbase": {
+		{"CGLD", "CELO"},
+	},
+}
+
+var (
+	registryOnce sync.Once
+	registryInst *registry
+)
+
+func getRegistry() *registry {
+	registryOnce.Do(func() {
+		registryInst = &registry{
+			venueSymbols: make(map[string]AssetId),
+			venueAssets:  make(map[string]string),
+			tokenAssets:  make(map[string]AssetId),
+			chainTokens:  make(map[string]string),
+			equivalences: make(map[AssetId]*Equivalence),
+		}
+
+		for _, equivalence := range equivalences {
+			registryInst.equivalences[equivalence.Variant] = equivalence
+		}
+
+		for venue, symbols := range venueSymbols {
+			for _, entry := range symbols {
+				registryInst.registerVenueSymbol(venue, entry.Symbol, entry.Asset)
+			}
+		}
+	})
+
+	return registryInst
+}
+
+func genVenueKey(venue string, symbol string) string {
+	return fmt.Sprintf("%v|%v", strings.ToLower(venue), strings.ToUpper(symbol))
+}
+
+func genTokenKey(chainId uint, address string) string {
+	return fmt.Sprintf("%v|%v", chainId, strings.ToLower(address))
+}
+
+func genChainAssetKey(chainId uint, asset AssetId) string {
+	return fmt.Sprintf("%v|%v", chainId, asset)
+}
+
+func (r *registry) registerVenueSymbol(venue string, symbol string, asset AssetId) {
+	r.venueSymbols[genVenueKey(venue, symbol)] = asset
+
+	assetKey := genVenueKey(venue, string(asset))
+	if _, found := r.venueAssets[assetKey]; !found {
+		r.venueAssets[assetKey] = symbol
+	}
+}
+
+// Maps a venue specific ticker to its canonical asset id
+func RegisterVenueSymbol(venue string, symbol string, asset AssetId) {
+	r := getRegistry()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.registerVenueSymbol(venue, symbol, asset)
+}
+
+// Maps a token contract on a chain to its canonical asset id
+func RegisterToken(chainId uint, address string, asset AssetId) {
+	r := getRegistry()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokenAssets[genTokenKey(chainId, address)] = asset
+	r.chainTokens[genChainAssetKey(chainId, asset)] = address
+}
+
+// Removes the mapping of a token contract
+func UnregisterToken(chainId uint, address string) {
+	r := getRegistry()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tokenKey := genTokenKey(chainId, address)
+	asset, found := r.tokenAssets[tokenKey]
+	if !found {
+		return
+	}
+	delete(r.tokenAssets, tokenKey)
+
+	chainKey := genChainAssetKey(chainId, asset)
+	if strings.EqualFold(r.chainTokens[chainKey], address) {
+		delete(r.chainTokens, chainKey)
+	}
+}
+
+// Returns the canonical asset id of a venue ticker.
+// Tickers without an explicit mapping are assumed to be canonical.
+func ToCanonical(venue string, symbol string) AssetId {
+	r := getRegistry()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if asset, found := r.venueSymbols[genVenueKey(venue, symbol)]; found {
+		return asset
+	}
+
+	return AssetId(symbol)
+}
+
+// Returns the ticker used by a venue for a canonical asset id
+func ToVenue(venue string, asset AssetId) string {
+	r := getRegistry()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if symbol, found := r.venueAssets[genVenueKey(venue, string(asset))]; found {
+		return symbol
+	}
+
+	return string(asset)
+}
+
+// Returns the canonical asset id of a token contract
+func TokenAsset(chainId uint, address string) (AssetId, error) {
+	r := getRegistry()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	asset, found := r.tokenAssets[genTokenKey(chainId, address)]
+	if !found {
+		return "", fmt.Errorf("unknown token with chainId=%v address=%v", chainId, address)
+	}
+
+	return asset, nil
+}
+
+// Returns the address of the token representing an asset on a chain.
+// If the asset has no token of its own (e.g. the native currency), a variant
+// linked by one of the allowed equivalence kinds is used instead.
+func ChainToken(chainId uint, asset AssetId, allowedKinds ...EquivalenceKind) (string, AssetId, error) {
+	r := getRegistry()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if address, found := r.chainTokens[genChainAssetKey(chainId, asset)]; found {
+		return address, asset, nil
+	}
+
+	for _, kind := range allowedKinds {
+		for _, equivalence := range r.equivalences {
+			if equivalence.Underlying != asset || equivalence.Kind != kind {
+				continue
+			}
+
+			address, found := r.chainTokens[genChainAssetKey(chainId, equivalence.Variant)]
+			if found {
+				return address, equivalence.Variant, nil
+			}
+		}
+	}
+
+	return "", "", fmt.Errorf("no token for asset=%v on chainId=%v", asset, chainId)
+}
+
+func GetEquivalence(variant AssetId) (*Equivalence, bool) {
+	r := getRegistry()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	equivalence, found := r.equivalences[variant]
+	return equivalence, found
+}
+
+// Follows equivalence rules of the allowed kinds down to the underlying asset
+func Underlying(asset AssetId, allowedKinds ...EquivalenceKind) AssetId {
+	for {
+		equivalence, found := GetEquivalence(asset)
+		if !found || !containsKind(allowedKinds, equivalence.Kind) {
+			return asset
+		}
+		asset = equivalence.Underlying
+	}
+}
+
+// Returns true if both assets share the same underlying asset under the allowed kinds
+func Equivalent(a AssetId, b AssetId, allowedKinds ...EquivalenceKind) bool {
+	return Underlying(a, allowedKinds...) == Underlying(b, allowedKinds...)
+}
+
+func containsKind(kinds []EquivalenceKind, kind EquivalenceKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,33 +1,23 @@
 package binanceHandler
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"regexp"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/assetRegistry"
 	"github.com/Opulentia-Trading/Arbitrage/platform/cexHandler"
 )
 
-const (
-	PlatformName      = "binance"
-	httpClientTimeout = 10 * time.Second
-)
+const PlatformName = "binance"
 
 var (
-	// Default client does not specify a timeout
-	httpClient = &http.Client{
-		Timeout: httpClientTimeout,
-	}
-
-	quoteRegexOnce sync.Once
-	quoteRegex     *regexp.Regexp
+	symbolsOnce sync.Once
+	symbolsMap  map[string]*binanceSymbol
 )
 
 // Implements the Platform interface
@@ -49,8 +39,15 @@ func NewBinanceHandler() *BinanceHandler {
 		TickerPrice:    "/api/v3/ticker/price?symbol=",
 	}
 
-	cexHandlerInst := cexHandler.NewCEXHandler(&exchangeInfo, baseUrl, apiKey, &endpoints)
-	initQuoteRegex(baseUrl)
+	// /api/* endpoints have a limit of 1200 request weight per minute.
+	// Ticker requests for all symbols weigh 2, so stay well below 20 requests per second.
+	clientConfig := cexHandler.RestClientConfig{
+		RequestsPerSecond: 10,
+		Burst:             20,
+	}
+
+	cexHandlerInst := cexHandler.NewCEXHandler(&exchangeInfo, baseUrl, apiKey, &endpoints, &clientConfig)
+	initSymbolsMap(cexHandlerInst.Client)
 	return &BinanceHandler{cexHandlerInst}
 }
 
@@ -59,33 +56,22 @@ func (h *BinanceHandler) GetExchangeInfo() *models.Exchange {
 }
 
 func (h *BinanceHandler) TestConnection() (string, error) {
-	url := h.BaseUrl + h.Endpoints.ApiTest
-	resp, err := httpClient.Get(url)
+	body, err := h.Client.Get(h.Endpoints.ApiTest, false)
 	if err != nil {
-		panic(err)
-	}
-
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		panic(err)
+		return "", err
 	}
 
 	return string(body), nil
 }
 
 func (h *BinanceHandler) FetchTickerInfoAll() ([]models.TickerInfo, error) {
-	url := h.BaseUrl + h.Endpoints.TickerPriceAll
-	resp, err := httpClient.Get(url)
+	body, err := h.Client.Get(h.Endpoints.TickerPriceAll, false)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	defer resp.Body.Close()
-
 	var result []models.TickerInfo
-	dec := json.NewDecoder(resp.Body)
+	dec := json.NewDecoder(bytes.NewReader(body))
 
 	// Read array open bracket
 	t, err := dec.Token()
@@ -105,10 +91,9 @@ func (h *BinanceHandler) FetchTickerInfoAll() ([]models.TickerInfo, error) {
 			panic(err)
 		}
 
-		symbolSplit := quoteRegex.FindAllStringSubmatch(ticker.Symbol, -1)
-		if len(symbolSplit) > 0 {
-			ticker.Base = symbolSplit[0][1]
-			ticker.Quote = symbolSplit[0][2]
+		if symbol, found := symbolsMap[ticker.Symbol]; found {
+			ticker.Base = canonicalAsset(symbol.BaseAsset)
+			ticker.Quote = canonicalAsset(symbol.QuoteAsset)
 		} else {
 			ticker.Base = ticker.Symbol
 			ticker.Quote = ticker.Symbol
@@ -128,23 +113,14 @@ func (h *BinanceHandler) FetchTickerInfoAll() ([]models.TickerInfo, error) {
 func (h *BinanceHandler) FetchTickerInfo(base string, quote string) (models.TickerInfo, error) {
 	var result models.TickerInfo
 
-	symbol := base + quote
-	url := h.BaseUrl + h.Endpoints.TickerPrice + symbol
-	resp, err := httpClient.Get(url)
-	if err != nil {
-		panic(err)
-	}
-
-	defer resp.Body.Close()
-
-	dec := json.NewDecoder(resp.Body)
-	err = dec.Decode(&result)
+	symbol := venueAsset(base) + venueAsset(quote)
+	err := h.Client.GetJson(h.Endpoints.TickerPrice+symbol, false, &result)
 	if err != nil {
-		panic(err)
+		return result, err
 	}
 
-	result.Base = base
-	result.Quote = quote
+	result.Base = canonicalAsset(venueAsset(base))
+	result.Quote = canonicalAsset(venueAsset(quote))
 
 	// TODO: Use GET /sapi/v1/asset/tradeFee signed endpoint
 	result.MakerComission = "0.001"
@@ -163,63 +139,54 @@ func (h *BinanceHandler) String() string {
 	return h.ExchangeInfo.Name
 }
 
+type binanceSymbol struct {
+	Symbol     string `json:"symbol"`
+	BaseAsset  string `json:"baseAsset"`
+	QuoteAsset string `json:"quoteAsset"`
+}
+
 type BinanceExchInfoResponse struct {
-	Symbols []struct {
-		Symbol     string `json:"symbol"`
-		BaseAsset  string `json:"baseAsset"`
-		QuoteAsset string `json:"quoteAsset"`
-	} `json:"symbols"`
+	Symbols []*binanceSymbol `json:"symbols"`
 }
 
-func initQuoteRegex(baseUrl string) {
-	quoteRegexOnce.Do(func() {
+func initSymbolsMap(client *cexHandler.RestClient) {
+	symbolsOnce.Do(func() {
 		// Guaranteed to run only once during program lifetime
-		quoteRegexStr, err := getQuoteRegexStr(baseUrl)
+		var err error
+		symbolsMap, err = getSymbolsMap(client)
 		if err != nil {
 			panic(err)
 		}
-		quoteRegex = regexp.MustCompile(quoteRegexStr)
 	})
 }
 
-func getQuoteRegexStr(baseUrl string) (string, error) {
-	url := baseUrl + "/api/v3/exchangeInfo"
-	resp, err := httpClient.Get(url)
-	if err != nil {
-		panic(err)
-	}
-
-	defer resp.Body.Close()
-
+// Binance symbols concatenate the base and quote assets without a delimiter (e.g. LINKETH).
+// The exchange info lists the assets of every symbol so they can be split unambiguously.
+func getSymbolsMap(client *cexHandler.RestClient) (map[string]*binanceSymbol, error) {
 	var respData BinanceExchInfoResponse
-	dec := json.NewDecoder(resp.Body)
-	err = dec.Decode(&respData)
+	err := client.GetJson("/api/v3/exchangeInfo", false, &respData)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	quoteAssetMap := make(map[string]bool)
-	var sb strings.Builder
-	sb.WriteString(`^(\w+)(`)
-	writeDelim := false
-
+	result := make(map[string]*binanceSymbol)
 	for _, symbol := range respData.Symbols {
-		if _, found := quoteAssetMap[symbol.QuoteAsset]; !found {
-			quoteAssetMap[symbol.QuoteAsset] = true
-			if writeDelim {
-				sb.WriteString(`|`)
-			}
-			sb.WriteString(symbol.QuoteAsset)
-			writeDelim = true
-		}
+		result[symbol.Symbol] = symbol
 	}
 
-	if len(quoteAssetMap) == 0 {
-		panic("could not retrieve quote assets")
+	if len(result) == 0 {
+		panic("could not retrieve symbols")
 	}
 
-	sb.WriteString(`)$`)
-	return sb.String(), nil
+	return result, nil
+}
+
+func canonicalAsset(binanceAsset string) string {
+	return string(assetRegistry.ToCanonical(PlatformName, binanceAsset))
+}
+
+func venueAsset(asset string) string {
+	return assetRegistry.ToVenue(PlatformName, assetRegistry.AssetId(asset))
 }
 
 // Binance Error Handling
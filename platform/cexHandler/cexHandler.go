@@ -6,6 +6,8 @@ type CexEndpointIdx struct {
 	ApiTest        string
 	TickerPriceAll string
 	TickerPrice    string
+	TradeFee       string
+	Order          string
 }
 
 type CexHandler struct {
@@ -13,13 +15,21 @@ type CexHandler struct {
 	BaseUrl      string
 	ApiKey       string
 	Endpoints    *CexEndpointIdx
+	Client       *RestClient
 }
 
-func NewCEXHandler(exchangeInfo *models.Exchange, baseUrl string, apiKey string, endpoints *CexEndpointIdx) *CexHandler {
+func NewCEXHandler(
+	exchangeInfo *models.Exchange,
+	baseUrl string,
+	apiKey string,
+	endpoints *CexEndpointIdx,
+	clientConfig *RestClientConfig,
+) *CexHandler {
 	return &CexHandler{
 		ExchangeInfo: exchangeInfo,
 		BaseUrl:      baseUrl,
 		ApiKey:       apiKey,
 		Endpoints:    endpoints,
+		Client:       NewRestClient(exchangeInfo.Name, baseUrl, clientConfig),
 	}
 }
@@ -0,0 +1,145 @@
+package coinbaseHandler
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/platform/cexHandler"
+)
+
+// Coinbase Advanced Trade accepts two kinds of API keys:
+// - CDP keys: the key name looks like "organizations/{org_id}/apiKeys/{key_id}" and the
+//   secret is an EC private key. Each request carries a short-lived ES256 JWT.
+// - Legacy keys: each request is signed with HMAC-SHA256 using the CB-ACCESS-* headers.
+// https://docs.cdp.coinbase.com/advanced-trade/docs/rest-api-auth
+
+const jwtExpiry = 2 * time.Minute
+
+func newRequestSigner(apiKey string, apiSecret string) (cexHandler.RequestSigner, error) {
+	if apiKey == "" || apiSecret == "" {
+		return nil, nil
+	}
+
+	// Secrets loaded from a .env file keep their newlines escaped
+	apiSecret = strings.ReplaceAll(apiSecret, `\n`, "\n")
+	if strings.Contains(apiSecret, "PRIVATE KEY") {
+		privateKey, err := parseEcPrivateKey(apiSecret)
+		if err != nil {
+			return nil, err
+		}
+		return &jwtSigner{keyName: apiKey, privateKey: privateKey}, nil
+	}
+
+	return &hmacSigner{apiKey: apiKey, apiSecret: apiSecret}, nil
+}
+
+func parseEcPrivateKey(pemStr string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemStr))
+	if block == nil {
+		return nil, errors.New("unable to decode coinbase api secret as PEM")
+	}
+
+	if privateKey, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("coinbase api secret is not an EC private key")
+	}
+
+	return privateKey, nil
+}
+
+type jwtSigner struct {
+	keyName    string
+	privateKey *ecdsa.PrivateKey
+}
+
+func (s *jwtSigner) Sign(req *http.Request, body []byte) ([]byte, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := map[string]string{
+		"alg":   "ES256",
+		"kid":   s.keyName,
+		"nonce": hex.EncodeToString(nonce),
+		"typ":   "JWT",
+	}
+
+	now := time.Now().Unix()
+	claims := map[string]interface{}{
+		"sub": s.keyName,
+		"iss": "cdp",
+		"nbf": now,
+		"exp": now + int64(jwtExpiry.Seconds()),
+		"uri": fmt.Sprintf("%v %v%v", req.Method, req.URL.Host, req.URL.Path),
+	}
+
+	headerJson, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	claimsJson, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJson) + "." +
+		base64.RawURLEncoding.EncodeToString(claimsJson)
+	hash := sha256.Sum256([]byte(signingInput))
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privateKey, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// ES256 signatures are the 32 byte big-endian r and s values concatenated
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	sig.FillBytes(signature[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return body, nil
+}
+
+type hmacSigner struct {
+	apiKey    string
+	apiSecret string
+}
+
+func (s *hmacSigner) Sign(req *http.Request, body []byte) ([]byte, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	// prehash = timestamp + method + requestPath + body
+	mac := hmac.New(sha256.New, []byte(s.apiSecret))
+	mac.Write([]byte(timestamp + req.Method + req.URL.Path))
+	mac.Write(body)
+
+	req.Header.Set("CB-ACCESS-KEY", s.apiKey)
+	req.Header.Set("CB-ACCESS-SIGN", hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("CB-ACCESS-TIMESTAMP", timestamp)
+	return body, nil
+}
@@ -0,0 +1,352 @@
+package coinbaseHandler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/assetRegistry"
+	"github.com/Opulentia-Trading/Arbitrage/platform/cexHandler"
+)
+
+const (
+	PlatformName = "coinbase"
+
+	// Fee rates of the lowest Advanced Trade pricing tier.
+	// Used when no API credentials are available to query the account's tier.
+	defaultMakerFeeRate = "0.004"
+	defaultTakerFeeRate = "0.006"
+)
+
+// Implements the Platform interface
+type CoinbaseHandler struct {
+	*cexHandler.CexHandler
+
+	feeRatesMu   sync.Mutex
+	makerFeeRate string
+	takerFeeRate string
+}
+
+type coinbaseProduct struct {
+	ProductId       string `json:"product_id"`
+	Price           string `json:"price"`
+	BaseCurrencyId  string `json:"base_currency_id"`
+	QuoteCurrencyId string `json:"quote_currency_id"`
+	BaseIncrement   string `json:"base_increment"`
+	QuoteIncrement  string `json:"quote_increment"`
+	TradingDisabled bool   `json:"trading_disabled"`
+}
+
+type coinbaseProductsResponse struct {
+	Products    []coinbaseProduct `json:"products"`
+	NumProducts int               `json:"num_products"`
+}
+
+type coinbaseTransactionSummary struct {
+	FeeTier struct {
+		PricingTier  string `json:"pricing_tier"`
+		MakerFeeRate string `json:"maker_fee_rate"`
+		TakerFeeRate string `json:"taker_fee_rate"`
+	} `json:"fee_tier"`
+}
+
+type coinbaseOrderRequest struct {
+	ClientOrderId      string                     `json:"client_order_id"`
+	ProductId          string                     `json:"product_id"`
+	Side               string                     `json:"side"`
+	OrderConfiguration coinbaseOrderConfiguration `json:"order_configuration"`
+}
+
+type coinbaseOrderConfiguration struct {
+	MarketIoc *coinbaseMarketIoc `json:"market_market_ioc,omitempty"`
+	LimitGtc  *coinbaseLimitGtc  `json:"limit_limit_gtc,omitempty"`
+	LimitGtd  *coinbaseLimitGtd  `json:"limit_limit_gtd,omitempty"`
+}
+
+type coinbaseMarketIoc struct {
+	BaseSize string `json:"base_size"`
+}
+
+type coinbaseLimitGtc struct {
+	BaseSize   string `json:"base_size"`
+	LimitPrice string `json:"limit_price"`
+	PostOnly   bool   `json:"post_only"`
+}
+
+type coinbaseLimitGtd struct {
+	BaseSize   string `json:"base_size"`
+	LimitPrice string `json:"limit_price"`
+	EndTime    string `json:"end_time"`
+	PostOnly   bool   `json:"post_only"`
+}
+
+type coinbaseOrderResponse struct {
+	Success         bool `json:"success"`
+	SuccessResponse struct {
+		OrderId       string `json:"order_id"`
+		ProductId     string `json:"product_id"`
+		Side          string `json:"side"`
+		ClientOrderId string `json:"client_order_id"`
+	} `json:"success_response"`
+	ErrorResponse struct {
+		Error        string `json:"error"`
+		Message      string `json:"message"`
+		ErrorDetails string `json:"error_details"`
+	} `json:"error_response"`
+}
+
+type coinbaseErrorResponse struct {
+	Error   string `json:"error"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func NewCoinbaseHandler() *CoinbaseHandler {
+	return NewCoinbaseHandlerWithUrl("https://api.coinbase.com")
+}
+
+// Creates a handler against a custom base url, e.g. the local mock server
+func NewCoinbaseHandlerWithUrl(baseUrl string) *CoinbaseHandler {
+	exchangeInfo := models.Exchange{
+		Type: models.Centralized,
+		Name: PlatformName,
+	}
+
+	apiKey := os.Getenv("COINBASE_API_KEY")
+	endpoints := cexHandler.CexEndpointIdx{
+		ApiTest:        "/api/v3/brokerage/time",
+		TickerPriceAll: "/api/v3/brokerage/market/products?product_type=SPOT",
+		TickerPrice:    "/api/v3/brokerage/market/products/",
+		TradeFee:       "/api/v3/brokerage/transaction_summary",
+		Order:          "/api/v3/brokerage/orders",
+	}
+
+	signer, err := newRequestSigner(apiKey, os.Getenv("COINBASE_API_SECRET"))
+	if err != nil {
+		panic(err)
+	}
+
+	// Public endpoints allow 10 requests per second per IP
+	clientConfig := cexHandler.RestClientConfig{
+		RequestsPerSecond: 10,
+		Burst:             10,
+		Signer:            signer,
+	}
+
+	cexHandlerInst := cexHandler.NewCEXHandler(&exchangeInfo, baseUrl, apiKey, &endpoints, &clientConfig)
+	return &CoinbaseHandler{CexHandler: cexHandlerInst}
+}
+
+func (h *CoinbaseHandler) GetExchangeInfo() *models.Exchange {
+	return h.ExchangeInfo
+}
+
+func (h *CoinbaseHandler) TestConnection() (string, error) {
+	body, err := h.Client.Get(h.Endpoints.ApiTest, false)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+func (h *CoinbaseHandler) FetchTickerInfoAll() ([]models.TickerInfo, error) {
+	var respData coinbaseProductsResponse
+	err := h.Client.GetJson(h.Endpoints.TickerPriceAll, false, &respData)
+	if err != nil {
+		return nil, err
+	}
+
+	makerFeeRate, takerFeeRate, err := h.getFeeRates()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.TickerInfo
+	for _, product := range respData.Products {
+		if product.Price == "" || product.TradingDisabled {
+			continue
+		}
+
+		ticker := product.tickerInfo()
+		ticker.MakerComission = makerFeeRate
+		ticker.TakerComission = takerFeeRate
+		result = append(result, ticker)
+	}
+
+	return result, nil
+}
+
+func (h *CoinbaseHandler) FetchTickerInfo(base string, quote string) (models.TickerInfo, error) {
+	product, err := h.fetchProduct(ProductId(base, quote))
+	if err != nil {
+		return models.TickerInfo{}, err
+	}
+
+	makerFeeRate, takerFeeRate, err := h.getFeeRates()
+	if err != nil {
+		return models.TickerInfo{}, err
+	}
+
+	result := product.tickerInfo()
+	result.MakerComission = makerFeeRate
+	result.TakerComission = takerFeeRate
+	return result, nil
+}
+
+func (h *CoinbaseHandler) ExecuteOrder(order models.Order) error {
+	if !h.Client.HasSigner() {
+		return errors.New("coinbase api credentials are not set")
+	}
+
+	var side string
+	switch order.Action {
+	case models.BuyLongSpot:
+		side = "BUY"
+	case models.SellLongSpot:
+		side = "SELL"
+	default:
+		return fmt.Errorf("unsupported action %v", order.Action)
+	}
+
+	if order.Quantity == nil || order.Quantity.Sign() <= 0 {
+		return errors.New("order quantity must be positive")
+	}
+
+	productId := ProductId(order.Base, order.Quote)
+	orderRequest := coinbaseOrderRequest{
+		ClientOrderId: newClientOrderId(),
+		ProductId:     productId,
+		Side:          side,
+	}
+
+	baseSize := order.Quantity.String()
+	if order.Price == nil {
+		orderRequest.OrderConfiguration.MarketIoc = &coinbaseMarketIoc{BaseSize: baseSize}
+	} else {
+		// Limit prices with more precision than the quote increment are rejected
+		product, err := h.fetchProduct(productId)
+		if err != nil {
+			return err
+		}
+		limitPrice := order.Price.FloatString(incrementDecimals(product.QuoteIncrement))
+
+		if order.Deadline > 0 {
+			orderRequest.OrderConfiguration.LimitGtd = &coinbaseLimitGtd{
+				BaseSize:   baseSize,
+				LimitPrice: limitPrice,
+				EndTime:    time.Now().Add(order.Deadline).UTC().Format(time.RFC3339),
+			}
+		} else {
+			orderRequest.OrderConfiguration.LimitGtc = &coinbaseLimitGtc{
+				BaseSize:   baseSize,
+				LimitPrice: limitPrice,
+			}
+		}
+	}
+
+	fmt.Printf("Executing %v/%v %v order\n", order.Base, order.Quote, order.Action.String())
+	// Orders are deduplicated by client_order_id so retrying is safe
+	var respData coinbaseOrderResponse
+	err := h.Client.PostJson(h.Endpoints.Order, orderRequest, true, true, &respData)
+	if err != nil {
+		return err
+	}
+
+	if !respData.Success {
+		return fmt.Errorf("coinbase order failed: %v %v", respData.ErrorResponse.Error, respData.ErrorResponse.Message)
+	}
+
+	fmt.Printf("order id: %v\n", respData.SuccessResponse.OrderId)
+	return nil
+}
+
+func (h *CoinbaseHandler) String() string {
+	return h.ExchangeInfo.Name
+}
+
+// Coinbase product ids use the BASE-QUOTE format
+func ProductId(base string, quote string) string {
+	baseSymbol := assetRegistry.ToVenue(PlatformName, assetRegistry.AssetId(strings.ToUpper(base)))
+	quoteSymbol := assetRegistry.ToVenue(PlatformName, assetRegistry.AssetId(strings.ToUpper(quote)))
+	return baseSymbol + "-" + quoteSymbol
+}
+
+func (h *CoinbaseHandler) fetchProduct(productId string) (*coinbaseProduct, error) {
+	var product coinbaseProduct
+	err := h.Client.GetJson(h.Endpoints.TickerPrice+productId, false, &product)
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// Fee rates depend on the account's 30 day volume so they are only fetched once
+func (h *CoinbaseHandler) getFeeRates() (string, string, error) {
+	h.feeRatesMu.Lock()
+	defer h.feeRatesMu.Unlock()
+
+	if h.makerFeeRate != "" {
+		return h.makerFeeRate, h.takerFeeRate, nil
+	}
+
+	if !h.Client.HasSigner() {
+		h.makerFeeRate = defaultMakerFeeRate
+		h.takerFeeRate = defaultTakerFeeRate
+		return h.makerFeeRate, h.takerFeeRate, nil
+	}
+
+	var respData coinbaseTransactionSummary
+	err := h.Client.GetJson(h.Endpoints.TradeFee, true, &respData)
+	if err != nil {
+		return "", "", err
+	}
+
+	h.makerFeeRate = respData.FeeTier.MakerFeeRate
+	h.takerFeeRate = respData.FeeTier.TakerFeeRate
+	return h.makerFeeRate, h.takerFeeRate, nil
+}
+
+func (p *coinbaseProduct) tickerInfo() models.TickerInfo {
+	base, quote := p.BaseCurrencyId, p.QuoteCurrencyId
+	if base == "" || quote == "" {
+		symbolSplit := strings.SplitN(p.ProductId, "-", 2)
+		if len(symbolSplit) == 2 {
+			base, quote = symbolSplit[0], symbolSplit[1]
+		}
+	}
+
+	return models.TickerInfo{
+		Symbol:    p.ProductId,
+		Base:      string(assetRegistry.ToCanonical(PlatformName, base)),
+		Quote:     string(assetRegistry.ToCanonical(PlatformName, quote)),
+		Price:     p.Price,
+		Timestamp: time.Now(),
+	}
+}
+
+// Returns the number of decimals of an increment such as "0.01"
+func incrementDecimals(increment string) int {
+	idx := strings.IndexByte(increment, '.')
+	if idx < 0 {
+		return 0
+	}
+
+	return len(strings.TrimRight(increment[idx+1:], "0"))
+}
+
+func newClientOrderId() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,252 @@
+package coinbaseHandler
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+)
+
+// Creates a handler against the mock server, signing with a legacy HMAC key unless credentials are disabled
+func newTestHandler(t *testing.T, withCredentials bool) (*CoinbaseHandler, *mockServer) {
+	t.Helper()
+
+	apiKey, apiSecret := "", ""
+	if withCredentials {
+		apiKey, apiSecret = "key", "secret"
+	}
+	t.Setenv("COINBASE_API_KEY", apiKey)
+	t.Setenv("COINBASE_API_SECRET", apiSecret)
+
+	server := newMockServer()
+	t.Cleanup(server.Close)
+
+	return NewCoinbaseHandlerWithUrl(server.URL), server
+}
+
+func TestProductId(t *testing.T) {
+	tests := []struct {
+		base  string
+		quote string
+		want  string
+	}{
+		{"BTC", "USD", "BTC-USD"},
+		{"eth", "usd", "ETH-USD"},
+		{"LINK", "ETH", "LINK-ETH"},
+		{"CELO", "USD", "CGLD-USD"},
+	}
+
+	for _, test := range tests {
+		if got := ProductId(test.base, test.quote); got != test.want {
+			t.Errorf("ProductId(%v, %v) = %v, want %v", test.base, test.quote, got, test.want)
+		}
+	}
+}
+
+func TestFetchTickerInfoAll(t *testing.T) {
+	handler, _ := newTestHandler(t, false)
+
+	tickers, err := handler.FetchTickerInfoAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bySymbol := make(map[string]models.TickerInfo)
+	for _, ticker := range tickers {
+		bySymbol[ticker.Symbol] = ticker
+	}
+
+	if _, ok := bySymbol["DOGE-USD"]; ok {
+		t.Error("products with trading disabled should be skipped")
+	}
+
+	tests := []struct {
+		symbol string
+		base   string
+		quote  string
+		price  string
+	}{
+		{"BTC-USD", "BTC", "USD", "19250.12"},
+		{"LINK-ETH", "LINK", "ETH", "0.005512"},
+		{"CGLD-USD", "CELO", "USD", "0.7521"},
+	}
+
+	for _, test := range tests {
+		ticker, ok := bySymbol[test.symbol]
+		if !ok {
+			t.Errorf("missing ticker %v", test.symbol)
+			continue
+		}
+
+		if ticker.Base != test.base || ticker.Quote != test.quote || ticker.Price != test.price {
+			t.Errorf("%v: got %v/%v at %v, want %v/%v at %v",
+				test.symbol, ticker.Base, ticker.Quote, ticker.Price, test.base, test.quote, test.price)
+		}
+	}
+}
+
+func TestFetchTickerInfoUnknownProduct(t *testing.T) {
+	handler, _ := newTestHandler(t, false)
+
+	if _, err := handler.FetchTickerInfo("FOO", "BAR"); err == nil {
+		t.Error("expected an error for an unknown product")
+	}
+}
+
+func TestFeeRates(t *testing.T) {
+	tests := []struct {
+		name            string
+		withCredentials bool
+		makerFeeRate    string
+		takerFeeRate    string
+	}{
+		// Without credentials the lowest tier is assumed
+		{"default tier", false, defaultMakerFeeRate, defaultTakerFeeRate},
+		// The mock account is on the "Advanced 1" tier
+		{"account tier", true, "0.0025", "0.004"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler, _ := newTestHandler(t, test.withCredentials)
+
+			ticker, err := handler.FetchTickerInfo("ETH", "USD")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ticker.MakerComission != test.makerFeeRate || ticker.TakerComission != test.takerFeeRate {
+				t.Errorf("got maker %v taker %v, want maker %v taker %v",
+					ticker.MakerComission, ticker.TakerComission, test.makerFeeRate, test.takerFeeRate)
+			}
+		})
+	}
+}
+
+func TestExecuteOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		order models.Order
+		check func(t *testing.T, request coinbaseOrderRequest)
+	}{
+		{
+			name: "market buy",
+			order: models.Order{
+				Base:     "BTC",
+				Quote:    "USD",
+				Action:   models.BuyLongSpot,
+				Quantity: big.NewInt(2),
+			},
+			check: func(t *testing.T, request coinbaseOrderRequest) {
+				if request.ProductId != "BTC-USD" || request.Side != "BUY" {
+					t.Errorf("got %v %v", request.Side, request.ProductId)
+				}
+				marketIoc := request.OrderConfiguration.MarketIoc
+				if marketIoc == nil || marketIoc.BaseSize != "2" {
+					t.Errorf("unexpected market configuration: %+v", request.OrderConfiguration)
+				}
+			},
+		},
+		{
+			name: "limit sell rounded to the quote increment",
+			order: models.Order{
+				Base:     "LINK",
+				Quote:    "ETH",
+				Action:   models.SellLongSpot,
+				Price:    big.NewRat(5512345, 1000000000),
+				Quantity: big.NewInt(10),
+			},
+			check: func(t *testing.T, request coinbaseOrderRequest) {
+				if request.ProductId != "LINK-ETH" || request.Side != "SELL" {
+					t.Errorf("got %v %v", request.Side, request.ProductId)
+				}
+				limitGtc := request.OrderConfiguration.LimitGtc
+				if limitGtc == nil || limitGtc.LimitPrice != "0.005512" || limitGtc.BaseSize != "10" {
+					t.Errorf("unexpected limit configuration: %+v", request.OrderConfiguration)
+				}
+			},
+		},
+		{
+			name: "limit buy with deadline",
+			order: models.Order{
+				Base:     "ETH",
+				Quote:    "USD",
+				Action:   models.BuyLongSpot,
+				Price:    big.NewRat(130547, 100),
+				Quantity: big.NewInt(1),
+				Deadline: time.Hour,
+			},
+			check: func(t *testing.T, request coinbaseOrderRequest) {
+				limitGtd := request.OrderConfiguration.LimitGtd
+				if limitGtd == nil || limitGtd.LimitPrice != "1305.47" {
+					t.Fatalf("unexpected limit configuration: %+v", request.OrderConfiguration)
+				}
+
+				endTime, err := time.Parse(time.RFC3339, limitGtd.EndTime)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if remaining := time.Until(endTime); remaining < 59*time.Minute || remaining > time.Hour {
+					t.Errorf("order ends in %v, want an hour", remaining)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler, server := newTestHandler(t, true)
+
+			if err := handler.ExecuteOrder(test.order); err != nil {
+				t.Fatal(err)
+			}
+
+			orders := server.Orders()
+			if len(orders) != 1 {
+				t.Fatalf("server received %v orders, want 1", len(orders))
+			}
+			if orders[0].ClientOrderId == "" {
+				t.Error("orders need a client order id to be deduplicated")
+			}
+			test.check(t, orders[0])
+		})
+	}
+}
+
+func TestExecuteOrderRejected(t *testing.T) {
+	tests := []struct {
+		name            string
+		withCredentials bool
+		order           models.Order
+	}{
+		{
+			name:            "no credentials",
+			withCredentials: false,
+			order:           models.Order{Base: "BTC", Quote: "USD", Action: models.BuyLongSpot, Quantity: big.NewInt(1)},
+		},
+		{
+			name:            "unsupported action",
+			withCredentials: true,
+			order:           models.Order{Base: "BTC", Quote: "USD", Action: models.BuyLongFutures, Quantity: big.NewInt(1)},
+		},
+		{
+			name:            "no quantity",
+			withCredentials: true,
+			order:           models.Order{Base: "BTC", Quote: "USD", Action: models.SellLongSpot},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler, server := newTestHandler(t, test.withCredentials)
+
+			if err := handler.ExecuteOrder(test.order); err == nil {
+				t.Error("expected the order to be rejected")
+			}
+			if orders := server.Orders(); len(orders) != 0 {
+				t.Errorf("server received %v orders, want none", len(orders))
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package coinbaseHandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+var mockProducts = []coinbaseProduct{
+	{
+		ProductId:       "BTC-USD",
+		Price:           "19250.12",
+		BaseCurrencyId:  "BTC",
+		QuoteCurrencyId: "USD",
+		BaseIncrement:   "0.00000001",
+		QuoteIncrement:  "0.01",
+	},
+	{
+		ProductId:       "ETH-USD",
+		Price:           "1305.47",
+		BaseCurrencyId:  "ETH",
+		QuoteCurrencyId: "USD",
+		BaseIncrement:   "0.00000001",
+		QuoteIncrement:  "0.01",
+	},
+	{
+		ProductId:       "LINK-ETH",
+		Price:           "0.005512",
+		BaseCurrencyId:  "LINK",
+		QuoteCurrencyId: "ETH",
+		BaseIncrement:   "0.01",
+		QuoteIncrement:  "0.000001",
+	},
+	{
+		ProductId:       "CGLD-USD",
+		Price:           "0.7521",
+		BaseCurrencyId:  "CGLD",
+		QuoteCurrencyId: "USD",
+		BaseIncrement:   "0.01",
+		QuoteIncrement:  "0.0001",
+	},
+	{
+		ProductId:       "DOGE-USD",
+		Price:           "0.0613",
+		BaseCurrencyId:  "DOGE",
+		QuoteCurrencyId: "USD",
+		BaseIncrement:   "0.1",
+		QuoteIncrement:  "0.00001",
+		TradingDisabled: true,
+	},
+}
+
+// Local mock of the Coinbase Advanced Trade endpoints used by the handler.
+// Usage:
+//
+//	server := newMockServer()
+//	defer server.Close()
+//	handler := NewCoinbaseHandlerWithUrl(server.URL)
+type mockServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	orders []coinbaseOrderRequest
+}
+
+func newMockServer() *mockServer {
+	server := &mockServer{}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v3/brokerage/time", func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		writeMockJson(w, http.StatusOK, map[string]interface{}{
+			"iso":          now.UTC().Format(time.RFC3339),
+			"epochSeconds": now.Unix(),
+			"epochMillis":  now.UnixMilli(),
+		})
+	})
+
+	mux.HandleFunc("/api/v3/brokerage/market/products", func(w http.ResponseWriter, r *http.Request) {
+		writeMockJson(w, http.StatusOK, coinbaseProductsResponse{
+			Products:    mockProducts,
+			NumProducts: len(mockProducts),
+		})
+	})
+
+	mux.HandleFunc("/api/v3/brokerage/market/products/", func(w http.ResponseWriter, r *http.Request) {
+		productId := strings.TrimPrefix(r.URL.Path, "/api/v3/brokerage/market/products/")
+		for _, product := range mockProducts {
+			if product.ProductId == productId {
+				writeMockJson(w, http.StatusOK, product)
+				return
+			}
+		}
+
+		writeMockJson(w, http.StatusNotFound, coinbaseErrorResponse{
+			Error:   "NOT_FOUND",
+			Code:    5,
+			Message: "ProductID is invalid",
+		})
+	})
+
+	mux.HandleFunc("/api/v3/brokerage/transaction_summary", func(w http.ResponseWriter, r *http.Request) {
+		if !mockAuthorized(w, r) {
+			return
+		}
+
+		var respData coinbaseTransactionSummary
+		respData.FeeTier.PricingTier = "Advanced 1"
+		respData.FeeTier.MakerFeeRate = "0.0025"
+		respData.FeeTier.TakerFeeRate = "0.004"
+		writeMockJson(w, http.StatusOK, respData)
+	})
+
+	mux.HandleFunc("/api/v3/brokerage/orders", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !mockAuthorized(w, r) {
+			return
+		}
+
+		var orderRequest coinbaseOrderRequest
+		var respData coinbaseOrderResponse
+		err := json.NewDecoder(r.Body).Decode(&orderRequest)
+		if err != nil || orderRequest.ProductId == "" || orderRequest.Side == "" {
+			respData.ErrorResponse.Error = "INVALID_ARGUMENT"
+			respData.ErrorResponse.Message = "malformed order request"
+			writeMockJson(w, http.StatusOK, respData)
+			return
+		}
+
+		server.mu.Lock()
+		server.orders = append(server.orders, orderRequest)
+		server.mu.Unlock()
+
+		respData.Success = true
+		respData.SuccessResponse.OrderId = "mock-" + orderRequest.ClientOrderId
+		respData.SuccessResponse.ProductId = orderRequest.ProductId
+		respData.SuccessResponse.Side = orderRequest.Side
+		respData.SuccessResponse.ClientOrderId = orderRequest.ClientOrderId
+		writeMockJson(w, http.StatusOK, respData)
+	})
+
+	server.Server = httptest.NewServer(mux)
+	return server
+}
+
+// Returns the orders accepted by the server
+func (s *mockServer) Orders() []coinbaseOrderRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]coinbaseOrderRequest(nil), s.orders...)
+}
+
+// Only checks that a request carries either JWT or HMAC credentials
+func mockAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	jwtAuth := strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ")
+	hmacAuth := r.Header.Get("CB-ACCESS-KEY") != "" &&
+		r.Header.Get("CB-ACCESS-SIGN") != "" &&
+		r.Header.Get("CB-ACCESS-TIMESTAMP") != ""
+
+	if !jwtAuth && !hmacAuth {
+		writeMockJson(w, http.StatusUnauthorized, coinbaseErrorResponse{
+			Error:   "UNAUTHENTICATED",
+			Code:    16,
+			Message: "Unauthenticated",
+		})
+		return false
+	}
+
+	return true
+}
+
+func writeMockJson(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,92 @@
+package cexHandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Error returned by an exchange, decoded from the HTTP status and error body
+type ExchangeError struct {
+	Platform   string
+	StatusCode int
+	Code       string
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *ExchangeError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%v request failed (status=%v): %v", e.Platform, e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("%v request failed (status=%v code=%v): %v", e.Platform, e.StatusCode, e.Code, e.Message)
+}
+
+// Server errors and rate limit violations can be retried after a cooldown
+func (e *ExchangeError) Retryable() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// Decodes an unsuccessful response into an ExchangeError.
+// Decoders may return nil for bodies they do not recognize, DefaultErrorDecoder is then used.
+type ErrorDecoder func(platform string, resp *http.Response, body []byte) *ExchangeError
+
+// Handles the error body formats used by most exchanges:
+// {"code": -1121, "msg": "Invalid symbol."} or {"error": "NOT_FOUND", "message": "..."}
+func DefaultErrorDecoder(platform string, resp *http.Response, body []byte) *ExchangeError {
+	exchangeError := &ExchangeError{
+		Platform:   platform,
+		StatusCode: resp.StatusCode,
+		Message:    string(body),
+		RetryAfter: parseRetryAfter(resp.Header),
+	}
+
+	var errBody struct {
+		Code    json.RawMessage `json:"code"`
+		Msg     string          `json:"msg"`
+		Error   string          `json:"error"`
+		Message string          `json:"message"`
+	}
+
+	if json.Unmarshal(body, &errBody) != nil {
+		return exchangeError
+	}
+
+	switch {
+	case errBody.Error != "":
+		exchangeError.Code = errBody.Error
+	case len(errBody.Code) > 0:
+		exchangeError.Code = unquote(errBody.Code)
+	}
+
+	switch {
+	case errBody.Msg != "":
+		exchangeError.Message = errBody.Msg
+	case errBody.Message != "":
+		exchangeError.Message = errBody.Message
+	}
+
+	return exchangeError
+}
+
+// The Retry-After header is given in seconds by the exchanges we use
+func parseRetryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+func unquote(raw json.RawMessage) string {
+	var str string
+	if json.Unmarshal(raw, &str) == nil {
+		return str
+	}
+
+	return string(raw)
+}
@@ -0,0 +1,74 @@
+package krakenHandler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Signs private requests with an increasing nonce.
+// Implements the cexHandler.RequestSigner interface
+type krakenSigner struct {
+	apiKey    string
+	apiSecret []byte
+
+	nonceMu   sync.Mutex
+	lastNonce uint64
+}
+
+func newKrakenSigner(apiKey string, apiSecretBase64 string) (*krakenSigner, error) {
+	// The API secret is distributed base64 encoded
+	apiSecret, err := base64.StdEncoding.DecodeString(apiSecretBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &krakenSigner{
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+	}, nil
+}
+
+// Nonces must strictly increase for each API key
+func (s *krakenSigner) nextNonce() uint64 {
+	s.nonceMu.Lock()
+	defer s.nonceMu.Unlock()
+
+	nonce := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	if nonce <= s.lastNonce {
+		nonce = s.lastNonce + 1
+	}
+
+	s.lastNonce = nonce
+	return nonce
+}
+
+// API-Sign = base64(HMAC-SHA512(uri path + SHA256(nonce + POST data), base64 decoded secret))
+// https://docs.kraken.com/rest/#section/Authentication/Headers-and-Signature
+func (s *krakenSigner) signature(path string, nonce string, postData string) string {
+	sha := sha256.Sum256([]byte(nonce + postData))
+
+	mac := hmac.New(sha512.New, s.apiSecret)
+	mac.Write([]byte(path))
+	mac.Write(sha[:])
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Prepends a fresh nonce to the form encoded body and signs it
+func (s *krakenSigner) Sign(req *http.Request, body []byte) ([]byte, error) {
+	nonce := strconv.FormatUint(s.nextNonce(), 10)
+	postData := "nonce=" + nonce
+	if len(body) > 0 {
+		postData += "&" + string(body)
+	}
+
+	req.Header.Set("API-Key", s.apiKey)
+	req.Header.Set("API-Sign", s.signature(req.URL.Path, nonce, postData))
+	return []byte(postData), nil
+}
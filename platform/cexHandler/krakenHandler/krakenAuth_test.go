@@ -0,0 +1,77 @@
+package krakenHandler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Example of https://docs.kraken.com/rest/#section/Authentication/Headers-and-Signature
+const (
+	exampleApiSecret = "kQH5HW/8p1uGOVjbgWA7FunAmGO8lsSUXNsu3eow76sz84Q18fWxnyRzBHCd3pd5nE9qa99HAZtuZuj6F1huXg=="
+	exampleNonce     = "1616492376594"
+	examplePostData  = "nonce=1616492376594&ordertype=limit&pair=XBTUSD&price=37500&type=buy&volume=1.25"
+	examplePath      = "/0/private/AddOrder"
+	exampleSignature = "4/dpxb3iT4tp/ZCVEwSnEsLxx0bqyhLpdfOpc6fn7OR8+UClSV5n9E6aSS8MPtnRfp32bAb0nmbRn6H8ndwLUQ=="
+)
+
+func TestKrakenSignature(t *testing.T) {
+	signer, err := newKrakenSigner("key", exampleApiSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := signer.signature(examplePath, exampleNonce, examplePostData); got != exampleSignature {
+		t.Errorf("got %v, want %v", got, exampleSignature)
+	}
+}
+
+func TestKrakenSign(t *testing.T) {
+	signer, err := newKrakenSigner("key", exampleApiSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := "ordertype=limit&pair=XBTUSD&price=37500&type=buy&volume=1.25"
+	var lastNonce uint64
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodPost, "https://api.kraken.com"+examplePath, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signed, err := signer.Sign(req, []byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The nonce is prepended to the body and strictly increases
+		postData := string(signed)
+		if !strings.HasPrefix(postData, "nonce=") || !strings.HasSuffix(postData, "&"+body) {
+			t.Fatalf("unexpected body %v", postData)
+		}
+		nonce := strings.TrimSuffix(strings.TrimPrefix(postData, "nonce="), "&"+body)
+		nonceValue, err := strconv.ParseUint(nonce, 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nonceValue <= lastNonce {
+			t.Errorf("nonce %v not above %v", nonceValue, lastNonce)
+		}
+		lastNonce = nonceValue
+
+		if got := req.Header.Get("API-Key"); got != "key" {
+			t.Errorf("got API-Key %v, want key", got)
+		}
+		if got, want := req.Header.Get("API-Sign"), signer.signature(examplePath, nonce, postData); got != want {
+			t.Errorf("got API-Sign %v, want %v", got, want)
+		}
+	}
+}
+
+func TestNewKrakenSignerInvalidSecret(t *testing.T) {
+	if _, err := newKrakenSigner("key", "not base64!"); err == nil {
+		t.Error("expected an error for a secret that is not base64 encoded")
+	}
+}
@@ -0,0 +1,373 @@
+package krakenHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/assetRegistry"
+	"github.com/Opulentia-Trading/Arbitrage/platform/cexHandler"
+)
+
+const (
+	PlatformName       = "kraken"
+	assetPairsEndpoint = "/0/public/AssetPairs"
+)
+
+// Implements the Platform interface
+type KrakenHandler struct {
+	*cexHandler.CexHandler
+
+	pairsMu sync.Mutex
+	pairs   *krakenPairs
+}
+
+// Kraken prefixes legacy asset codes with X (crypto) or Z (fiat), and uses XBT/XDG
+// where every other venue uses BTC/DOGE. Pair names are built from these codes
+// (XXBTZUSD, XETHXXBT), so pairs are looked up by their canonical assets instead
+// of building a base + quote symbol.
+
+// Tradable asset pairs indexed by pair name and by canonical assets, not modified once loaded
+type krakenPairs struct {
+	byName   map[string]*krakenPair
+	byAssets map[string]*krakenPair
+}
+
+type krakenPair struct {
+	Name         string      `json:"-"`
+	Altname      string      `json:"altname"`
+	Wsname       string      `json:"wsname"`
+	Base         string      `json:"base"`
+	Quote        string      `json:"quote"`
+	PairDecimals int         `json:"pair_decimals"`
+	LotDecimals  int         `json:"lot_decimals"`
+	Fees         [][]float64 `json:"fees"`
+	FeesMaker    [][]float64 `json:"fees_maker"`
+	Status       string      `json:"status"`
+}
+
+type krakenResponse struct {
+	Error  []string        `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+type krakenTicker struct {
+	Ask       []string `json:"a"`
+	Bid       []string `json:"b"`
+	LastTrade []string `json:"c"`
+}
+
+type krakenAddOrderResult struct {
+	Descr struct {
+		Order string `json:"order"`
+	} `json:"descr"`
+	Txid []string `json:"txid"`
+}
+
+func NewKrakenHandler() *KrakenHandler {
+	return NewKrakenHandlerWithUrl("https://api.kraken.com")
+}
+
+// Creates a handler against a custom base url, e.g. a local mock server
+func NewKrakenHandlerWithUrl(baseUrl string) *KrakenHandler {
+	exchangeInfo := models.Exchange{
+		Type: models.Centralized,
+		Name: PlatformName,
+	}
+
+	apiKey := os.Getenv("KRAKEN_API_KEY")
+	endpoints := cexHandler.CexEndpointIdx{
+		ApiTest:        "/0/public/Time",
+		TickerPriceAll: "/0/public/Ticker",
+		TickerPrice:    "/0/public/Ticker?pair=",
+		Order:          "/0/private/AddOrder",
+	}
+
+	// Public endpoints allow about 1 request per second
+	clientConfig := cexHandler.RestClientConfig{
+		RequestsPerSecond: 1,
+		Burst:             3,
+	}
+
+	apiSecret := os.Getenv("KRAKEN_API_SECRET")
+	if apiKey != "" && apiSecret != "" {
+		signer, err := newKrakenSigner(apiKey, apiSecret)
+		if err != nil {
+			panic(err)
+		}
+		clientConfig.Signer = signer
+	}
+
+	cexHandlerInst := cexHandler.NewCEXHandler(&exchangeInfo, baseUrl, apiKey, &endpoints, &clientConfig)
+	return &KrakenHandler{CexHandler: cexHandlerInst}
+}
+
+func (h *KrakenHandler) GetExchangeInfo() *models.Exchange {
+	return h.ExchangeInfo
+}
+
+func (h *KrakenHandler) TestConnection() (string, error) {
+	result, err := h.doPublic(h.Endpoints.ApiTest)
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
+func (h *KrakenHandler) FetchTickerInfoAll() ([]models.TickerInfo, error) {
+	pairs, err := h.loadPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.doPublic(h.Endpoints.TickerPriceAll)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickers map[string]krakenTicker
+	err = json.Unmarshal(result, &tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickerInfoAll []models.TickerInfo
+	for pairName, ticker := range tickers {
+		pair, found := pairs.byName[pairName]
+		if !found || len(ticker.LastTrade) == 0 {
+			continue
+		}
+
+		tickerInfoAll = append(tickerInfoAll, pair.tickerInfo(ticker))
+	}
+
+	return tickerInfoAll, nil
+}
+
+func (h *KrakenHandler) FetchTickerInfo(base string, quote string) (models.TickerInfo, error) {
+	pair, err := h.getPair(base, quote)
+	if err != nil {
+		return models.TickerInfo{}, err
+	}
+
+	result, err := h.doPublic(h.Endpoints.TickerPrice + pair.Name)
+	if err != nil {
+		return models.TickerInfo{}, err
+	}
+
+	var tickers map[string]krakenTicker
+	err = json.Unmarshal(result, &tickers)
+	if err != nil {
+		return models.TickerInfo{}, err
+	}
+
+	ticker, found := tickers[pair.Name]
+	if !found || len(ticker.LastTrade) == 0 {
+		return models.TickerInfo{}, fmt.Errorf("missing ticker for kraken pair %v", pair.Name)
+	}
+
+	return pair.tickerInfo(ticker), nil
+}
+
+func (h *KrakenHandler) ExecuteOrder(order models.Order) error {
+	var orderType string
+	switch order.Action {
+	case models.BuyLongSpot:
+		orderType = "buy"
+	case models.SellLongSpot:
+		orderType = "sell"
+	default:
+		return fmt.Errorf("unsupported action %v", order.Action)
+	}
+
+	if order.Quantity == nil || order.Quantity.Sign() <= 0 {
+		return errors.New("order quantity must be positive")
+	}
+
+	pair, err := h.getPair(order.Base, order.Quote)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("pair", pair.Name)
+	form.Set("type", orderType)
+	form.Set("volume", order.Quantity.String())
+
+	if order.Price == nil {
+		form.Set("ordertype", "market")
+	} else {
+		form.Set("ordertype", "limit")
+		form.Set("price", order.Price.FloatString(pair.PairDecimals))
+		if order.Deadline > 0 {
+			// Relative expiration time in seconds
+			form.Set("expiretm", fmt.Sprintf("+%v", int64(order.Deadline.Seconds())))
+		}
+	}
+
+	fmt.Printf("Executing %v/%v %v order\n", order.Base, order.Quote, order.Action.String())
+	result, err := h.doPrivate(h.Endpoints.Order, form)
+	if err != nil {
+		return err
+	}
+
+	var orderResult krakenAddOrderResult
+	err = json.Unmarshal(result, &orderResult)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("order: %v\n", orderResult.Descr.Order)
+	fmt.Printf("txid: %v\n", strings.Join(orderResult.Txid, ","))
+	return nil
+}
+
+func (h *KrakenHandler) String() string {
+	return h.ExchangeInfo.Name
+}
+
+// Loads the tradable asset pairs once
+func (h *KrakenHandler) loadPairs() (*krakenPairs, error) {
+	h.pairsMu.Lock()
+	defer h.pairsMu.Unlock()
+
+	if h.pairs != nil {
+		return h.pairs, nil
+	}
+
+	result, err := h.doPublic(assetPairsEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := parseAssetPairs(result)
+	if err != nil {
+		return nil, err
+	}
+
+	h.pairs = pairs
+	return pairs, nil
+}
+
+// Indexes the result of the AssetPairs endpoint by pair name and by normalized assets
+func parseAssetPairs(result json.RawMessage) (*krakenPairs, error) {
+	var pairs map[string]*krakenPair
+	err := json.Unmarshal(result, &pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed := &krakenPairs{
+		byName:   make(map[string]*krakenPair),
+		byAssets: make(map[string]*krakenPair),
+	}
+	for name, pair := range pairs {
+		// Dark pool pairs (e.g. XXBTZUSD.d) share assets with the regular pair
+		if strings.HasSuffix(name, ".d") {
+			continue
+		}
+
+		pair.Name = name
+		indexed.byName[name] = pair
+		indexed.byAssets[genPairsKey(canonicalAsset(pair.Base), canonicalAsset(pair.Quote))] = pair
+	}
+
+	return indexed, nil
+}
+
+func (h *KrakenHandler) getPair(base string, quote string) (*krakenPair, error) {
+	pairs, err := h.loadPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	key := genPairsKey(canonicalAsset(base), canonicalAsset(quote))
+	pair, found := pairs.byAssets[key]
+	if !found {
+		return nil, fmt.Errorf("unknown kraken pair with base=%v quote=%v", base, quote)
+	}
+
+	return pair, nil
+}
+
+func genPairsKey(base string, quote string) string {
+	return base + "/" + quote
+}
+
+func canonicalAsset(krakenAsset string) string {
+	return string(assetRegistry.ToCanonical(PlatformName, krakenAsset))
+}
+
+func (h *KrakenHandler) doPublic(endpoint string) (json.RawMessage, error) {
+	body, err := h.Client.Get(endpoint, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResult(body)
+}
+
+func (h *KrakenHandler) doPrivate(endpoint string, form url.Values) (json.RawMessage, error) {
+	// Orders are not idempotent, a retried request could place a second order
+	body, err := h.Client.PostForm(endpoint, form, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResult(body)
+}
+
+// Kraken returns HTTP 200 for API errors and lists them in the error field
+func decodeResult(body []byte) (json.RawMessage, error) {
+	var respData krakenResponse
+	err := json.Unmarshal(body, &respData)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(respData.Error) > 0 {
+		return nil, &cexHandler.ExchangeError{
+			Platform:   PlatformName,
+			StatusCode: http.StatusOK,
+			Code:       respData.Error[0],
+			Message:    strings.Join(respData.Error, ", "),
+		}
+	}
+
+	return respData.Result, nil
+}
+
+func (p *krakenPair) tickerInfo(ticker krakenTicker) models.TickerInfo {
+	return models.TickerInfo{
+		Symbol:         p.Name,
+		Base:           canonicalAsset(p.Base),
+		Quote:          canonicalAsset(p.Quote),
+		Price:          ticker.LastTrade[0],
+		MakerComission: feeScheduleRate(p.FeesMaker, p.Fees),
+		TakerComission: feeScheduleRate(p.Fees, p.Fees),
+		Timestamp:      time.Now(),
+	}
+}
+
+// Returns the fee of the lowest volume tier as a fraction.
+// Kraken publishes fee schedules as [volume, percent fee] tiers.
+func feeScheduleRate(schedule [][]float64, fallback [][]float64) string {
+	if len(schedule) == 0 || len(schedule[0]) < 2 {
+		schedule = fallback
+	}
+
+	if len(schedule) == 0 || len(schedule[0]) < 2 {
+		return ""
+	}
+
+	return strconv.FormatFloat(schedule[0][1]/100, 'f', -1, 64)
+}
@@ -0,0 +1,203 @@
+package krakenHandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+)
+
+// Excerpt of the result of the AssetPairs endpoint
+const mockAssetPairs = `{
+	"XXBTZUSD": {
+		"altname": "XBTUSD", "wsname": "XBT/USD", "base": "XXBT", "quote": "ZUSD",
+		"pair_decimals": 1, "lot_decimals": 8,
+		"fees": [[0, 0.26], [50000, 0.24]], "fees_maker": [[0, 0.16], [50000, 0.14]],
+		"status": "online"
+	},
+	"XXBTZUSD.d": {
+		"altname": "XBTUSD.d", "base": "XXBT", "quote": "ZUSD",
+		"pair_decimals": 1, "lot_decimals": 8,
+		"fees": [[0, 0.36]], "fees_maker": [[0, 0.36]]
+	},
+	"XETHXXBT": {
+		"altname": "ETHXBT", "wsname": "ETH/XBT", "base": "XETH", "quote": "XXBT",
+		"pair_decimals": 5, "lot_decimals": 8,
+		"fees": [[0, 0.26]], "fees_maker": [[0, 0.16]],
+		"status": "online"
+	},
+	"XDGUSD": {
+		"altname": "XDGUSD", "wsname": "XDG/USD", "base": "XXDG", "quote": "ZUSD",
+		"pair_decimals": 7, "lot_decimals": 8,
+		"fees": [[0, 0.26]],
+		"status": "online"
+	}
+}`
+
+const mockTickers = `{
+	"XXBTZUSD": {"a": ["30300.1", "1", "1.000"], "b": ["30300.0", "1", "1.000"], "c": ["30300.0", "0.001"]},
+	"XXBTZUSD.d": {"a": ["30301.0", "1", "1.000"], "b": ["30299.0", "1", "1.000"], "c": ["30301.0", "0.5"]},
+	"XETHXXBT": {"a": ["0.06401", "1", "1.000"], "b": ["0.06400", "1", "1.000"], "c": ["0.06400", "0.1"]},
+	"XDGUSD": {"a": ["0.0613", "1", "1.000"], "b": ["0.0612", "1", "1.000"], "c": ["0.0612", "100"]},
+	"NEWPAIR": {"a": ["1.0", "1", "1.000"], "b": ["1.0", "1", "1.000"], "c": ["1.0", "1"]}
+}`
+
+// Creates a handler without credentials against a mock of the public endpoints
+func newTestHandler(t *testing.T) *KrakenHandler {
+	t.Helper()
+
+	t.Setenv("KRAKEN_API_KEY", "")
+	t.Setenv("KRAKEN_API_SECRET", "")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(assetPairsEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error": [], "result": ` + mockAssetPairs + `}`))
+	})
+	mux.HandleFunc("/0/public/Ticker", func(w http.ResponseWriter, r *http.Request) {
+		if pair := r.URL.Query().Get("pair"); pair != "" && pair != "XXBTZUSD" {
+			w.Write([]byte(`{"error": ["EQuery:Unknown asset pair"]}`))
+			return
+		}
+		w.Write([]byte(`{"error": [], "result": ` + mockTickers + `}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return NewKrakenHandlerWithUrl(server.URL)
+}
+
+func TestParseAssetPairs(t *testing.T) {
+	pairs, err := parseAssetPairs([]byte(mockAssetPairs))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found := pairs.byName["XXBTZUSD.d"]; found {
+		t.Error("dark pool pairs should be skipped")
+	}
+	if len(pairs.byName) != 3 || len(pairs.byAssets) != 3 {
+		t.Errorf("got %v pairs by name and %v by assets, want 3", len(pairs.byName), len(pairs.byAssets))
+	}
+
+	tests := []struct {
+		assets string
+		name   string
+	}{
+		{"BTC/USD", "XXBTZUSD"},
+		{"ETH/BTC", "XETHXXBT"},
+		{"DOGE/USD", "XDGUSD"},
+	}
+
+	for _, test := range tests {
+		pair, found := pairs.byAssets[test.assets]
+		if !found {
+			t.Errorf("missing pair %v", test.assets)
+			continue
+		}
+		if pair.Name != test.name {
+			t.Errorf("%v: got %v, want %v", test.assets, pair.Name, test.name)
+		}
+	}
+}
+
+func TestFeeScheduleRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule [][]float64
+		fallback [][]float64
+		want     string
+	}{
+		{"lowest volume tier", [][]float64{{0, 0.16}, {50000, 0.14}}, [][]float64{{0, 0.26}}, "0.0016"},
+		{"missing schedule uses the fallback", nil, [][]float64{{0, 0.26}}, "0.0026"},
+		{"malformed tier uses the fallback", [][]float64{{0}}, [][]float64{{0, 0.26}}, "0.0026"},
+		{"no schedule", nil, nil, ""},
+	}
+
+	for _, test := range tests {
+		if got := feeScheduleRate(test.schedule, test.fallback); got != test.want {
+			t.Errorf("%v: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFetchTickerInfoAll(t *testing.T) {
+	handler := newTestHandler(t)
+
+	// The pairs are loaded once while tickers are read concurrently
+	var wg sync.WaitGroup
+	results := make([][]models.TickerInfo, 2)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			tickers, err := handler.FetchTickerInfoAll()
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = tickers
+		}(i)
+	}
+	wg.Wait()
+
+	for _, tickers := range results {
+		bySymbol := make(map[string]models.TickerInfo)
+		for _, ticker := range tickers {
+			bySymbol[ticker.Symbol] = ticker
+		}
+
+		// Dark pool pairs and pairs listed after the pairs were loaded are skipped
+		if len(bySymbol) != 3 {
+			t.Errorf("got tickers %v, want 3", len(bySymbol))
+		}
+
+		tests := []struct {
+			symbol string
+			base   string
+			quote  string
+			price  string
+			maker  string
+			taker  string
+		}{
+			{"XXBTZUSD", "BTC", "USD", "30300.0", "0.0016", "0.0026"},
+			{"XETHXXBT", "ETH", "BTC", "0.06400", "0.0016", "0.0026"},
+			{"XDGUSD", "DOGE", "USD", "0.0612", "0.0026", "0.0026"},
+		}
+
+		for _, test := range tests {
+			ticker, found := bySymbol[test.symbol]
+			if !found {
+				t.Errorf("missing ticker %v", test.symbol)
+				continue
+			}
+
+			if ticker.Base != test.base || ticker.Quote != test.quote || ticker.Price != test.price {
+				t.Errorf("%v: got %v/%v at %v, want %v/%v at %v",
+					test.symbol, ticker.Base, ticker.Quote, ticker.Price, test.base, test.quote, test.price)
+			}
+			if ticker.MakerComission != test.maker || ticker.TakerComission != test.taker {
+				t.Errorf("%v: got maker %v taker %v, want maker %v taker %v",
+					test.symbol, ticker.MakerComission, ticker.TakerComission, test.maker, test.taker)
+			}
+		}
+	}
+}
+
+func TestFetchTickerInfo(t *testing.T) {
+	handler := newTestHandler(t)
+
+	ticker, err := handler.FetchTickerInfo("BTC", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticker.Symbol != "XXBTZUSD" || ticker.Price != "30300.0" {
+		t.Errorf("got %v at %v, want XXBTZUSD at 30300.0", ticker.Symbol, ticker.Price)
+	}
+
+	if _, err := handler.FetchTickerInfo("BTC", "JPY"); err == nil {
+		t.Error("expected an error for an unknown pair")
+	}
+}
@@ -0,0 +1,82 @@
+package cexHandler
+
+import (
+	"sync"
+	"time"
+)
+
+// Token bucket limiting the request rate to a single venue
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64 // tokens per second
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Blocks until a request may be sent
+func (l *rateLimiter) Wait() {
+	if l == nil || l.rate <= 0 {
+		return
+	}
+
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return
+		}
+		time.Sleep(delay)
+	}
+}
+
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Holds back all requests for a cooldown period, e.g. after an HTTP 429 response
+func (l *rateLimiter) Pause(cooldown time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pausedUntil := time.Now().Add(cooldown)
+	if pausedUntil.After(l.pausedUntil) {
+		l.pausedUntil = pausedUntil
+	}
+}
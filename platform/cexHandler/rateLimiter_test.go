@@ -0,0 +1,67 @@
+package cexHandler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(10, 3)
+
+	// The burst is available at once
+	for i := 0; i < 3; i++ {
+		if delay := limiter.reserve(); delay != 0 {
+			t.Fatalf("request %v of the burst: got delay %v, want 0", i, delay)
+		}
+	}
+
+	// Then the bucket refills at the rate, one request every 100ms
+	if delay := limiter.reserve(); delay <= 0 || delay > 100*time.Millisecond {
+		t.Errorf("got delay %v, want at most 100ms", delay)
+	}
+
+	// Tokens accumulated while idle are capped at the burst
+	limiter.last = limiter.last.Add(-time.Minute)
+	for i := 0; i < 3; i++ {
+		if delay := limiter.reserve(); delay != 0 {
+			t.Fatalf("request %v after idling: got delay %v, want 0", i, delay)
+		}
+	}
+	if delay := limiter.reserve(); delay <= 0 {
+		t.Errorf("got delay %v after the burst, want a delay", delay)
+	}
+}
+
+func TestRateLimiterPause(t *testing.T) {
+	limiter := newRateLimiter(10, 3)
+	limiter.Pause(time.Second)
+
+	if delay := limiter.reserve(); delay <= 900*time.Millisecond || delay > time.Second {
+		t.Errorf("got delay %v, want about 1s", delay)
+	}
+
+	// A shorter pause does not shorten the current one
+	limiter.Pause(time.Millisecond)
+	if delay := limiter.reserve(); delay <= 900*time.Millisecond {
+		t.Errorf("got delay %v, want about 1s", delay)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := newRateLimiter(100, 1)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		limiter.Wait()
+	}
+
+	// The first request uses the burst, the other four wait 10ms each
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("5 requests took %v, want about 40ms", elapsed)
+	}
+
+	// A nil limiter does not limit
+	var disabled *rateLimiter
+	disabled.Wait()
+	disabled.Pause(time.Second)
+}
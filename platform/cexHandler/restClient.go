@@ -0,0 +1,300 @@
+package cexHandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultTimeout        = 10 * time.Second
+	defaultMaxRetries     = 3
+	defaultRetryBaseDelay = 250 * time.Millisecond
+	maxLoggedBodyLength   = 512
+)
+
+// Adds authentication to a request. The returned body replaces the request body,
+// which allows signers to add fields such as a nonce to the payload.
+// Signers are called again on every retry so timestamps and nonces stay fresh.
+type RequestSigner interface {
+	Sign(req *http.Request, body []byte) ([]byte, error)
+}
+
+type RestClientConfig struct {
+	Timeout           time.Duration
+	MaxRetries        int
+	RetryBaseDelay    time.Duration
+	RequestsPerSecond float64 // 0 disables rate limiting
+	Burst             int
+	Signer            RequestSigner
+	ErrorDecoder      ErrorDecoder
+	Logger            *log.Logger // logs requests, responses and retries, the standard logger if not set
+}
+
+type Request struct {
+	Method      string
+	Endpoint    string // path relative to the base url, may include a query string
+	Query       url.Values
+	Body        []byte
+	ContentType string
+	Signed      bool
+	Idempotent  bool // allows retrying non-GET requests
+}
+
+// REST client shared by the CEX handlers.
+// Handles signing, rate limiting, retries of server and transport errors and error decoding
+// so that a new CEX handler mostly has to map its endpoints.
+type RestClient struct {
+	platform       string
+	baseUrl        string
+	httpClient     *http.Client
+	maxRetries     int
+	retryBaseDelay time.Duration
+	limiter        *rateLimiter
+	signer         RequestSigner
+	errorDecoder   ErrorDecoder
+	logger         *log.Logger
+}
+
+func NewRestClient(platform string, baseUrl string, config *RestClientConfig) *RestClient {
+	if config == nil {
+		config = &RestClientConfig{}
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	maxRetries := config.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	retryBaseDelay := config.RetryBaseDelay
+	if retryBaseDelay == 0 {
+		retryBaseDelay = defaultRetryBaseDelay
+	}
+
+	errorDecoder := config.ErrorDecoder
+	if errorDecoder == nil {
+		errorDecoder = DefaultErrorDecoder
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	var limiter *rateLimiter
+	if config.RequestsPerSecond > 0 {
+		limiter = newRateLimiter(config.RequestsPerSecond, config.Burst)
+	}
+
+	return &RestClient{
+		platform: platform,
+		baseUrl:  baseUrl,
+		// Default client does not specify a timeout
+		httpClient:     &http.Client{Timeout: timeout},
+		maxRetries:     maxRetries,
+		retryBaseDelay: retryBaseDelay,
+		limiter:        limiter,
+		signer:         config.Signer,
+		errorDecoder:   errorDecoder,
+		logger:         logger,
+	}
+}
+
+func (c *RestClient) HasSigner() bool {
+	return c.signer != nil
+}
+
+func (c *RestClient) Get(endpoint string, signed bool) ([]byte, error) {
+	return c.Do(&Request{
+		Method:   http.MethodGet,
+		Endpoint: endpoint,
+		Signed:   signed,
+	})
+}
+
+// Sends a GET request and decodes the JSON response into out
+func (c *RestClient) GetJson(endpoint string, signed bool, out interface{}) error {
+	body, err := c.Get(endpoint, signed)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// Sends a POST request with a JSON body and decodes the JSON response into out
+func (c *RestClient) PostJson(endpoint string, data interface{}, signed bool, idempotent bool, out interface{}) error {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.Do(&Request{
+		Method:      http.MethodPost,
+		Endpoint:    endpoint,
+		Body:        reqBody,
+		ContentType: "application/json",
+		Signed:      signed,
+		Idempotent:  idempotent,
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// Sends a POST request with a form encoded body
+func (c *RestClient) PostForm(endpoint string, form url.Values, signed bool, idempotent bool) ([]byte, error) {
+	return c.Do(&Request{
+		Method:      http.MethodPost,
+		Endpoint:    endpoint,
+		Body:        []byte(form.Encode()),
+		ContentType: "application/x-www-form-urlencoded",
+		Signed:      signed,
+		Idempotent:  idempotent,
+	})
+}
+
+func (c *RestClient) Do(req *Request) ([]byte, error) {
+	if req.Signed && c.signer == nil {
+		return nil, errors.New(c.platform + " api credentials are not set")
+	}
+
+	retryable := req.Method == http.MethodGet || req.Idempotent
+	for attempt := 0; ; attempt++ {
+		body, err := c.doOnce(req)
+		if err == nil {
+			return body, nil
+		}
+
+		var retryAfter time.Duration
+		var exchangeError *ExchangeError
+		var transportError *transportError
+		switch {
+		case errors.As(err, &exchangeError) && exchangeError.Retryable():
+			retryAfter = exchangeError.RetryAfter
+			if retryAfter > 0 {
+				c.limiter.Pause(retryAfter)
+			}
+		case errors.As(err, &transportError):
+		default:
+			return nil, err
+		}
+
+		// A request that is not idempotent may have reached the exchange before the connection failed
+		if !retryable || attempt >= c.maxRetries {
+			return nil, err
+		}
+
+		delay := c.retryDelay(attempt, retryAfter)
+		c.logf("retrying %v %v in %v (attempt %v/%v): %v", req.Method, req.Endpoint, delay, attempt+1, c.maxRetries, err)
+		time.Sleep(delay)
+	}
+}
+
+func (c *RestClient) doOnce(req *Request) ([]byte, error) {
+	reqUrl := c.baseUrl + req.Endpoint
+	if len(req.Query) > 0 {
+		reqUrl += "?" + req.Query.Encode()
+	}
+
+	httpReq, err := http.NewRequest(req.Method, reqUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ContentType != "" {
+		httpReq.Header.Set("Content-Type", req.ContentType)
+	}
+
+	reqBody := req.Body
+	if req.Signed {
+		reqBody, err = c.signer.Sign(httpReq, reqBody)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(reqBody) > 0 {
+		httpReq.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+		httpReq.ContentLength = int64(len(reqBody))
+	}
+
+	c.limiter.Wait()
+	start := time.Now()
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, &transportError{err}
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &transportError{err}
+	}
+
+	c.logf("%v %v => %v (%v) %v", req.Method, req.Endpoint, resp.StatusCode, time.Since(start), truncate(body))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// A nil result must not become a non-nil error interface holding a nil pointer
+		exchangeError := c.errorDecoder(c.platform, resp, body)
+		if exchangeError == nil {
+			exchangeError = DefaultErrorDecoder(c.platform, resp, body)
+		}
+		return nil, exchangeError
+	}
+
+	return body, nil
+}
+
+// Error sending a request or reading its response, such as a timeout or a connection reset
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// Exponential backoff with jitter, bounded below by the exchange's Retry-After
+func (c *RestClient) retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	backoff := c.retryBaseDelay << uint(attempt)
+	delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+	if delay < retryAfter {
+		delay = retryAfter
+	}
+
+	return delay
+}
+
+func (c *RestClient) logf(format string, args ...interface{}) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.Printf("[%v] "+format, append([]interface{}{c.platform}, args...)...)
+}
+
+func truncate(body []byte) string {
+	if len(body) > maxLoggedBodyLength {
+		return string(body[:maxLoggedBodyLength]) + "..."
+	}
+
+	return string(body)
+}
@@ -0,0 +1,177 @@
+package cexHandler
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestErrorDecoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "INVALID_ARGUMENT", "message": "` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+
+	// Only recognizes errors of the /known endpoint
+	decoder := func(platform string, resp *http.Response, body []byte) *ExchangeError {
+		if resp.Request.URL.Path != "/known" {
+			return nil
+		}
+		return &ExchangeError{Platform: platform, StatusCode: resp.StatusCode, Code: "KNOWN"}
+	}
+
+	client := NewRestClient("test", server.URL, &RestClientConfig{ErrorDecoder: decoder})
+
+	tests := []struct {
+		endpoint string
+		code     string
+	}{
+		{"/known", "KNOWN"},
+		// The default decoder is used when the custom decoder returns nil
+		{"/unknown", "INVALID_ARGUMENT"},
+	}
+
+	for _, test := range tests {
+		_, err := client.Get(test.endpoint, false)
+		if err == nil {
+			t.Fatalf("%v: expected an error for status 400", test.endpoint)
+		}
+
+		var exchangeError *ExchangeError
+		if !errors.As(err, &exchangeError) || exchangeError == nil {
+			t.Fatalf("%v: expected an ExchangeError, got %#v", test.endpoint, err)
+		}
+
+		if exchangeError.Code != test.code || exchangeError.StatusCode != http.StatusBadRequest {
+			t.Errorf("%v: got code %v status %v, want code %v status 400",
+				test.endpoint, exchangeError.Code, exchangeError.StatusCode, test.code)
+		}
+	}
+}
+
+// Returns a client of a server answering with the given statuses in turn, and the number of requests it received
+func newRetryTestClient(t *testing.T, statuses ...int) (*RestClient, *int32) {
+	t.Helper()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := int(atomic.AddInt32(&requests, 1)) - 1
+		if attempt >= len(statuses) {
+			attempt = len(statuses) - 1
+		}
+		w.WriteHeader(statuses[attempt])
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewRestClient("test", server.URL, &RestClientConfig{
+		MaxRetries:     2,
+		RetryBaseDelay: time.Millisecond,
+		Logger:         log.New(ioutil.Discard, "", 0),
+	})
+
+	return client, &requests
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		method       string
+		idempotent   bool
+		wantErr      bool
+		wantRequests int32
+	}{
+		{name: "rate limited then ok", statuses: []int{429, 200}, method: http.MethodGet, wantRequests: 2},
+		{name: "server errors then ok", statuses: []int{500, 503, 200}, method: http.MethodGet, wantRequests: 3},
+		{name: "server errors until the retries run out", statuses: []int{502}, method: http.MethodGet, wantErr: true, wantRequests: 3},
+		{name: "client error", statuses: []int{400, 200}, method: http.MethodGet, wantErr: true, wantRequests: 1},
+		{name: "not found", statuses: []int{404, 200}, method: http.MethodGet, wantErr: true, wantRequests: 1},
+		{name: "post", statuses: []int{500, 200}, method: http.MethodPost, wantErr: true, wantRequests: 1},
+		{name: "idempotent post", statuses: []int{500, 200}, method: http.MethodPost, idempotent: true, wantRequests: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, requests := newRetryTestClient(t, test.statuses...)
+
+			_, err := client.Do(&Request{Method: test.method, Endpoint: "/", Idempotent: test.idempotent})
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error %v", err, test.wantErr)
+			}
+
+			if got := atomic.LoadInt32(requests); got != test.wantRequests {
+				t.Errorf("got %v requests, want %v", got, test.wantRequests)
+			}
+		})
+	}
+}
+
+// Connections closed before a response are retried like server errors
+func TestRetryTransportErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient("test", server.URL, &RestClientConfig{
+		RetryBaseDelay: time.Millisecond,
+		Logger:         log.New(ioutil.Discard, "", 0),
+	})
+
+	if _, err := client.Get("/", false); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("got %v requests, want 2", got)
+	}
+
+	// A request that is not idempotent may have been executed
+	atomic.StoreInt32(&requests, 0)
+	_, err := client.Do(&Request{Method: http.MethodPost, Endpoint: "/"})
+	var transportError *transportError
+	if !errors.As(err, &transportError) {
+		t.Errorf("got %v, want a transport error", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("got %v requests, want 1", got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	client := NewRestClient("test", "", &RestClientConfig{RetryBaseDelay: 100 * time.Millisecond})
+
+	for attempt := 0; attempt < 4; attempt++ {
+		backoff := 100 * time.Millisecond << uint(attempt)
+		for i := 0; i < 100; i++ {
+			// Jitter keeps the delay within the upper half of the backoff
+			if delay := client.retryDelay(attempt, 0); delay < backoff/2 || delay > backoff {
+				t.Fatalf("attempt %v: got %v, want between %v and %v", attempt, delay, backoff/2, backoff)
+			}
+		}
+	}
+
+	// The exchange's Retry-After is a lower bound
+	if delay := client.retryDelay(0, 5*time.Second); delay != 5*time.Second {
+		t.Errorf("got %v, want the Retry-After of 5s", delay)
+	}
+	if delay := client.retryDelay(3, time.Millisecond); delay < 400*time.Millisecond {
+		t.Errorf("got %v, want the backoff when above the Retry-After", delay)
+	}
+}
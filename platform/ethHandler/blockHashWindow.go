@@ -0,0 +1,80 @@
+package ethHandler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Hashes of recently processed blocks, used by state derived from logs to find
+// the last block it shares with the canonical chain after a reorg.
+// Blocks older than MaxReorgDepth below the newest block are forgotten.
+// Not safe for concurrent use.
+type BlockHashWindow struct {
+	hashes map[uint64]common.Hash
+	newest uint64
+}
+
+func NewBlockHashWindow() *BlockHashWindow {
+	return &BlockHashWindow{hashes: make(map[uint64]common.Hash)}
+}
+
+func (w *BlockHashWindow) Add(number uint64, hash common.Hash) {
+	w.hashes[number] = hash
+	if number <= w.newest {
+		return
+	}
+
+	w.newest = number
+	for known := range w.hashes {
+		if known+MaxReorgDepth <= number {
+			delete(w.hashes, known)
+		}
+	}
+}
+
+// Forgets the blocks after a block
+func (w *BlockHashWindow) Rollback(number uint64) {
+	for known := range w.hashes {
+		if known > number {
+			delete(w.hashes, known)
+		}
+	}
+
+	if w.newest > number {
+		w.newest = number
+	}
+}
+
+// Reads the headers of the chain, implemented by the client of the handler
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// Returns the newest block of the window still on the canonical chain.
+// Returns ErrReorgTooDeep if no block of the window is, including when the window is empty.
+func (w *BlockHashWindow) FindForkPoint(ctx context.Context, headers HeaderReader) (uint64, error) {
+	numbers := make([]uint64, 0, len(w.hashes))
+	for number := range w.hashes {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+
+	for _, number := range numbers {
+		header, err := headers.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return 0, err
+		}
+
+		if header.Hash() == w.hashes[number] {
+			return number, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: none of %v recent blocks is canonical", ErrReorgTooDeep, len(numbers))
+}
@@ -0,0 +1,266 @@
+package ethHandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	headPollInterval = 2 * time.Second
+	MaxReorgDepth    = 64 // recent blocks kept to find the fork point of a reorg
+)
+
+var ErrReorgTooDeep = errors.New("reorg is deeper than the window of recent blocks")
+
+// A block of the canonical chain, delivered once and in order
+type BlockEvent struct {
+	Header *types.Header
+	Reorg  *Reorg // set on the first block of a new branch, nil otherwise
+}
+
+// Blocks of the previous branch replaced by a reorg
+type Reorg struct {
+	ForkPoint *types.Header   // last block shared by both branches
+	Removed   []*types.Header // in ascending order
+}
+
+func (e *BlockEvent) Number() uint64 {
+	return e.Header.Number.Uint64()
+}
+
+// Returns call options pinning contract reads to the block, so that every read
+// made for the block sees the same state
+func (e *BlockEvent) CallOpts(ctx context.Context) *bind.CallOpts {
+	return e.Snapshot(ctx).CallOpts()
+}
+
+func (r *Reorg) Depth() int {
+	return len(r.Removed)
+}
+
+// Delivers the blocks of the canonical chain as they are mined.
+// New heads are received over WebSockets, the latest block is polled over Https or if the subscription fails.
+// Gaps between heads are filled and reorgs are detected by following the parent hashes of new heads.
+type BlockSubscription struct {
+	handler *EthHandler
+	headers HeaderReader
+	events  chan *BlockEvent
+	err     chan error
+	cancel  context.CancelFunc
+	chain   []*types.Header // recent canonical blocks in ascending order
+}
+
+func (e *EthHandler) SubscribeBlocks(ctx context.Context) *BlockSubscription {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &BlockSubscription{
+		handler: e,
+		headers: e.Client,
+		events:  make(chan *BlockEvent),
+		err:     make(chan error, 1),
+		cancel:  cancel,
+	}
+
+	go s.run(ctx)
+	return s
+}
+
+// Both channels are closed when the subscription ends
+func (s *BlockSubscription) Events() <-chan *BlockEvent {
+	return s.events
+}
+
+// Receives the error that ended the subscription, if any
+func (s *BlockSubscription) Err() <-chan error {
+	return s.err
+}
+
+func (s *BlockSubscription) Unsubscribe() {
+	s.cancel()
+}
+
+func (s *BlockSubscription) run(ctx context.Context) {
+	defer close(s.err)
+	defer close(s.events)
+
+	err := s.follow(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.err <- err
+	}
+}
+
+func (s *BlockSubscription) follow(ctx context.Context) error {
+	if s.handler.ProviderProtocol == WebSockets {
+		err := s.watchHeads(ctx)
+		if err == nil || ctx.Err() != nil || errors.Is(err, ErrReorgTooDeep) {
+			return err
+		}
+
+		fmt.Printf("new head subscription failed, falling back to polling: %v\n", err)
+	}
+
+	return s.pollHeads(ctx)
+}
+
+func (s *BlockSubscription) watchHeads(ctx context.Context) error {
+	heads := make(chan *types.Header)
+	sub, err := s.handler.Client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case head := <-heads:
+			if err := s.processHead(ctx, head); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *BlockSubscription) pollHeads(ctx context.Context) error {
+	ticker := time.NewTicker(headPollInterval)
+	defer ticker.Stop()
+
+	for {
+		head, err := s.handler.Client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		if err := s.processHead(ctx, head); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Delivers a new head and the blocks missed before it.
+// The parent hashes of the head are followed back to a block of the window of recent blocks,
+// the blocks of the window after that block were replaced by a reorg.
+// The window starts with the first head and is extended with its ancestors when a reorg
+// reaches below it, so only reorgs deeper than MaxReorgDepth fail.
+func (s *BlockSubscription) processHead(ctx context.Context, head *types.Header) error {
+	if len(s.chain) == 0 {
+		s.chain = append(s.chain, head)
+		return s.emit(ctx, &BlockEvent{Header: head})
+	}
+
+	if known := s.canonicalHeader(head.Number.Uint64()); known != nil && known.Hash() == head.Hash() {
+		return nil
+	}
+
+	// New blocks in descending order
+	branch := []*types.Header{head}
+	forkIndex := -1
+	for forkIndex < 0 {
+		oldest := branch[len(branch)-1]
+		parentNumber := oldest.Number.Uint64() - 1
+		if parentNumber < s.chain[0].Number.Uint64() {
+			if len(s.chain) >= MaxReorgDepth || s.chain[0].Number.Sign() == 0 {
+				return fmt.Errorf("%w: block %v (%v)", ErrReorgTooDeep, head.Number, head.Hash())
+			}
+
+			ancestor, err := s.headers.HeaderByHash(ctx, s.chain[0].ParentHash)
+			if err != nil {
+				return err
+			}
+			s.chain = append([]*types.Header{ancestor}, s.chain...)
+			continue
+		}
+
+		if parent := s.canonicalHeader(parentNumber); parent != nil && parent.Hash() == oldest.ParentHash {
+			forkIndex = int(parentNumber - s.chain[0].Number.Uint64())
+			break
+		}
+
+		parent, err := s.headers.HeaderByHash(ctx, oldest.ParentHash)
+		if err != nil {
+			return err
+		}
+		branch = append(branch, parent)
+	}
+
+	var reorg *Reorg
+	if removed := s.chain[forkIndex+1:]; len(removed) > 0 {
+		reorg = &Reorg{
+			ForkPoint: s.chain[forkIndex],
+			Removed:   append([]*types.Header(nil), removed...),
+		}
+	}
+
+	s.chain = s.chain[:forkIndex+1]
+	for i := len(branch) - 1; i >= 0; i-- {
+		s.chain = append(s.chain, branch[i])
+	}
+	if len(s.chain) > MaxReorgDepth {
+		s.chain = append([]*types.Header(nil), s.chain[len(s.chain)-MaxReorgDepth:]...)
+	}
+
+	for i := len(branch) - 1; i >= 0; i-- {
+		event := &BlockEvent{Header: branch[i]}
+		if i == len(branch)-1 {
+			event.Reorg = reorg
+		}
+
+		if err := s.emit(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the block of the window at a height, or nil
+func (s *BlockSubscription) canonicalHeader(number uint64) *types.Header {
+	first := s.chain[0].Number.Uint64()
+	if number < first || number-first >= uint64(len(s.chain)) {
+		return nil
+	}
+
+	return s.chain[number-first]
+}
+
+func (s *BlockSubscription) emit(ctx context.Context, event *BlockEvent) error {
+	select {
+	case s.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Calls fn once for every block of the canonical chain until the context is cancelled,
+// fn returns an error or the subscription fails.
+// Blocks are processed one at a time, new heads are delivered after fn returns.
+func (e *EthHandler) ForEachBlock(ctx context.Context, fn func(event *BlockEvent) error) error {
+	sub := e.SubscribeBlocks(ctx)
+	defer sub.Unsubscribe()
+
+	for event := range sub.Events() {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+
+	if err, ok := <-sub.Err(); ok {
+		return err
+	}
+
+	return nil
+}
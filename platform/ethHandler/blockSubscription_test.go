@@ -0,0 +1,260 @@
+package ethHandler
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Headers of every branch by hash, and of the canonical branch by number
+type fakeChain struct {
+	byHash    map[common.Hash]*types.Header
+	canonical map[uint64]*types.Header
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		byHash:    make(map[common.Hash]*types.Header),
+		canonical: make(map[uint64]*types.Header),
+	}
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if header, found := c.canonical[number.Uint64()]; found {
+		return header, nil
+	}
+
+	return nil, ethereum.NotFound
+}
+
+func (c *fakeChain) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	if header, found := c.byHash[hash]; found {
+		return header, nil
+	}
+
+	return nil, ethereum.NotFound
+}
+
+// Adds count blocks of a branch after a parent and makes them canonical.
+// Branches are told apart by their extra data.
+func (c *fakeChain) extend(parent *types.Header, branch string, count int) []*types.Header {
+	headers := make([]*types.Header, 0, count)
+	for i := 0; i < count; i++ {
+		header := &types.Header{
+			Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+			ParentHash: parent.Hash(),
+			Extra:      []byte(branch),
+		}
+		c.byHash[header.Hash()] = header
+		c.canonical[header.Number.Uint64()] = header
+		headers = append(headers, header)
+		parent = header
+	}
+
+	return headers
+}
+
+// Returns a chain whose canonical branch "a" has blocks 0 to count-1
+func newTestChain(count int) (*fakeChain, []*types.Header) {
+	chain := newFakeChain()
+	genesis := &types.Header{Number: big.NewInt(0), Extra: []byte("a")}
+	chain.byHash[genesis.Hash()] = genesis
+	chain.canonical[0] = genesis
+
+	return chain, append([]*types.Header{genesis}, chain.extend(genesis, "a", count-1)...)
+}
+
+func newTestSubscription(chain *fakeChain) *BlockSubscription {
+	return &BlockSubscription{
+		headers: chain,
+		events:  make(chan *BlockEvent, 2*MaxReorgDepth),
+	}
+}
+
+// Processes a head and returns the events it delivered
+func processTestHead(t *testing.T, s *BlockSubscription, head *types.Header) []*BlockEvent {
+	t.Helper()
+
+	if err := s.processHead(context.Background(), head); err != nil {
+		t.Fatalf("head %v: %v", head.Number, err)
+	}
+
+	var events []*BlockEvent
+	for len(s.events) > 0 {
+		events = append(events, <-s.events)
+	}
+
+	return events
+}
+
+func assertBlockEvents(t *testing.T, events []*BlockEvent, want []*types.Header) {
+	t.Helper()
+
+	var got, wantHashes []common.Hash
+	for _, event := range events {
+		got = append(got, event.Header.Hash())
+	}
+	for _, header := range want {
+		wantHashes = append(wantHashes, header.Hash())
+	}
+
+	if !reflect.DeepEqual(got, wantHashes) {
+		t.Errorf("got blocks %v, want %v", got, wantHashes)
+	}
+}
+
+func assertReorg(t *testing.T, reorg *Reorg, forkPoint *types.Header, removed []*types.Header) {
+	t.Helper()
+
+	if reorg == nil {
+		t.Fatal("no reorg")
+	}
+	if reorg.ForkPoint.Hash() != forkPoint.Hash() {
+		t.Errorf("fork point: got %v, want %v", reorg.ForkPoint.Number, forkPoint.Number)
+	}
+	if reorg.Depth() != len(removed) {
+		t.Fatalf("depth: got %v, want %v", reorg.Depth(), len(removed))
+	}
+	for i := range removed {
+		if reorg.Removed[i].Hash() != removed[i].Hash() {
+			t.Errorf("removed block %v: got %v, want %v", i, reorg.Removed[i].Number, removed[i].Number)
+		}
+	}
+}
+
+func TestProcessHeadInOrder(t *testing.T) {
+	chain, blocks := newTestChain(12)
+	s := newTestSubscription(chain)
+
+	assertBlockEvents(t, processTestHead(t, s, blocks[10]), blocks[10:11])
+	assertBlockEvents(t, processTestHead(t, s, blocks[11]), blocks[11:12])
+
+	// A head already delivered is skipped
+	assertBlockEvents(t, processTestHead(t, s, blocks[11]), nil)
+}
+
+// The parent hashes of a head are followed back to the window to fill the gap
+func TestProcessHeadGap(t *testing.T) {
+	chain, blocks := newTestChain(8)
+	s := newTestSubscription(chain)
+	processTestHead(t, s, blocks[4])
+
+	events := processTestHead(t, s, blocks[7])
+	assertBlockEvents(t, events, blocks[5:8])
+	for _, event := range events {
+		if event.Reorg != nil {
+			t.Errorf("block %v: unexpected reorg", event.Number())
+		}
+	}
+}
+
+func TestProcessHeadReorg(t *testing.T) {
+	chain, blocks := newTestChain(12)
+	s := newTestSubscription(chain)
+	for _, block := range blocks[8:12] {
+		processTestHead(t, s, block)
+	}
+
+	// Blocks 10 and 11 are replaced by three blocks of branch b
+	branch := chain.extend(blocks[9], "b", 3)
+	events := processTestHead(t, s, branch[2])
+
+	assertBlockEvents(t, events, branch)
+	assertReorg(t, events[0].Reorg, blocks[9], blocks[10:12])
+	for _, event := range events[1:] {
+		if event.Reorg != nil {
+			t.Errorf("block %v: reorg on a block after the first of the branch", event.Number())
+		}
+	}
+}
+
+// The window is extended with the ancestors of the first head
+func TestProcessHeadReorgOfFirstBlock(t *testing.T) {
+	chain, blocks := newTestChain(11)
+	s := newTestSubscription(chain)
+	processTestHead(t, s, blocks[10])
+
+	branch := chain.extend(blocks[9], "b", 1)
+	events := processTestHead(t, s, branch[0])
+
+	assertBlockEvents(t, events, branch)
+	assertReorg(t, events[0].Reorg, blocks[9], blocks[10:11])
+}
+
+func TestProcessHeadReorgBelowFirstBlock(t *testing.T) {
+	chain, blocks := newTestChain(12)
+	s := newTestSubscription(chain)
+	processTestHead(t, s, blocks[10])
+	processTestHead(t, s, blocks[11])
+
+	// Depth 3 reorg two blocks after startup
+	branch := chain.extend(blocks[8], "b", 4)
+	events := processTestHead(t, s, branch[3])
+
+	assertBlockEvents(t, events, branch)
+	assertReorg(t, events[0].Reorg, blocks[8], blocks[9:12])
+}
+
+func TestProcessHeadReorgTooDeep(t *testing.T) {
+	chain, blocks := newTestChain(MaxReorgDepth + 10)
+	s := newTestSubscription(chain)
+	for _, block := range blocks[1:] {
+		processTestHead(t, s, block)
+	}
+
+	branch := chain.extend(blocks[5], "b", len(blocks)-5)
+	err := s.processHead(context.Background(), branch[len(branch)-1])
+	if !errors.Is(err, ErrReorgTooDeep) {
+		t.Errorf("got %v, want %v", err, ErrReorgTooDeep)
+	}
+}
+
+func TestFindForkPoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  []int // blocks of branch a added to the window
+		forkAt  int   // branch b replaces the blocks after it, -1 for no reorg
+		want    uint64
+		wantErr error
+	}{
+		{name: "no reorg", window: []int{10, 11, 12}, forkAt: -1, want: 12},
+		{name: "newest block replaced", window: []int{10, 11, 12}, forkAt: 11, want: 11},
+		{name: "reorg within the window", window: []int{10, 11, 12}, forkAt: 10, want: 10},
+		{name: "every block replaced", window: []int{10, 11, 12}, forkAt: 9, wantErr: ErrReorgTooDeep},
+		{name: "empty window", forkAt: -1, wantErr: ErrReorgTooDeep},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain, blocks := newTestChain(13)
+			window := NewBlockHashWindow()
+			for _, number := range test.window {
+				window.Add(uint64(number), blocks[number].Hash())
+			}
+			if test.forkAt >= 0 {
+				chain.extend(blocks[test.forkAt], "b", 12-test.forkAt)
+			}
+
+			got, err := window.FindForkPoint(context.Background(), chain)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Errorf("got %v, want %v", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
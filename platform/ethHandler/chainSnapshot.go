@@ -0,0 +1,44 @@
+package ethHandler
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// A block that a set of reads is pinned to, so that they all see the same chain state
+type ChainSnapshot struct {
+	BlockNumber *big.Int
+	Context     context.Context
+}
+
+// Returns a snapshot of a block
+func SnapshotAt(blockNumber uint64) *ChainSnapshot {
+	return &ChainSnapshot{BlockNumber: new(big.Int).SetUint64(blockNumber)}
+}
+
+// Returns a snapshot of the latest block. Reads made through the snapshot keep reading
+// that block after new blocks are mined.
+func (e *EthHandler) LatestSnapshot() (*ChainSnapshot, error) {
+	blockNumber, err := e.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return SnapshotAt(blockNumber), nil
+}
+
+// Returns a snapshot of the block of a block event
+func (e *BlockEvent) Snapshot(ctx context.Context) *ChainSnapshot {
+	return &ChainSnapshot{BlockNumber: e.Header.Number, Context: ctx}
+}
+
+func (s *ChainSnapshot) Number() uint64 {
+	return s.BlockNumber.Uint64()
+}
+
+// Returns call options reading the block of the snapshot
+func (s *ChainSnapshot) CallOpts() *bind.CallOpts {
+	return &bind.CallOpts{BlockNumber: new(big.Int).Set(s.BlockNumber), Context: s.Context}
+}
@@ -0,0 +1,91 @@
+package ethHandler
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	gethMath "github.com/ethereum/go-ethereum/common/math"
+)
+
+const MaxSlippageBps = 10000
+
+var bpsDenominator = big.NewInt(10000)
+
+// Parameters of a DEX order given in human readable units.
+// The DEX handlers turn them into a models.Order with amounts in wei.
+type OrderParams struct {
+	Base        string
+	Quote       string
+	Action      models.Action // BuyLongSpot or SellLongSpot
+	Quantity    string        // amount of the base asset to buy or sell, e.g. "1.5"
+	SwapKind    models.SwapKind
+	SlippageBps uint // maximum slippage from the expected amounts in basis points
+	Deadline    time.Duration
+}
+
+func (p *OrderParams) Validate() error {
+	if p.Action != models.BuyLongSpot && p.Action != models.SellLongSpot {
+		return fmt.Errorf("unsupported action %v", p.Action)
+	}
+
+	// The base quantity is the input of a sell
+	if p.Action == models.SellLongSpot && p.SwapKind == models.ExactOutput {
+		return fmt.Errorf("exact output is only supported for buy orders")
+	}
+
+	if p.SlippageBps >= MaxSlippageBps {
+		return fmt.Errorf("invalid slippage of %v bps", p.SlippageBps)
+	}
+
+	return nil
+}
+
+// Returns the minimum output amount accepted with the given slippage, rounded down
+func AmountOutMin(amountOut *big.Int, slippageBps uint) *big.Int {
+	result := new(big.Int).Mul(amountOut, big.NewInt(int64(MaxSlippageBps-slippageBps)))
+	return result.Quo(result, bpsDenominator)
+}
+
+// Returns the maximum input amount accepted with the given slippage, rounded up
+func AmountInMax(amountIn *big.Int, slippageBps uint) *big.Int {
+	result := new(big.Int).Mul(amountIn, big.NewInt(int64(MaxSlippageBps+slippageBps)))
+	result.Add(result, new(big.Int).Sub(bpsDenominator, big.NewInt(1)))
+	return result.Quo(result, bpsDenominator)
+}
+
+// Converts a human readable amount (e.g. "1.5") to the smallest unit of the token
+func (t *Token) ParseAmount(amount string) (*big.Int, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %v amount: %v", t.Symbol, amount)
+	}
+
+	value.Mul(value, new(big.Rat).SetInt(gethMath.BigPow(10, int64(t.Decimals))))
+	if !value.IsInt() {
+		return nil, fmt.Errorf("%v amount %v has more than %v decimals", t.Symbol, amount, t.Decimals)
+	}
+
+	return value.Num(), nil
+}
+
+// Converts an amount in the smallest unit of the token to a human readable amount
+func (t *Token) FormatAmount(amount *big.Int) string {
+	value := new(big.Rat).SetFrac(amount, gethMath.BigPow(10, int64(t.Decimals)))
+	return value.FloatString(int(t.Decimals))
+}
+
+// Returns the price of the base token in quote tokens given amounts in their smallest units
+func ExecutionPrice(baseToken *Token, baseAmount *big.Int, quoteToken *Token, quoteAmount *big.Int) *big.Rat {
+	price := new(big.Rat).SetFrac(quoteAmount, baseAmount)
+	diffDecimals := int64(baseToken.Decimals) - int64(quoteToken.Decimals)
+	if diffDecimals >= 0 {
+		price.Mul(price, new(big.Rat).SetInt(gethMath.BigPow(10, diffDecimals)))
+	} else {
+		price.Quo(price, new(big.Rat).SetInt(gethMath.BigPow(10, -diffDecimals)))
+	}
+
+	return price
+}
@@ -1,7 +1,6 @@
 package ethHandler
 
 import (
-	"context"
 	"fmt"
 	"math/big"
 	"time"
@@ -45,6 +44,42 @@ func (e *ERC20Handler) BalanceOf(account common.Address) (*big.Int, error) {
 	return e.Contract.BalanceOf(&bind.CallOpts{}, account)
 }
 
+// Returns the balances of an account for a list of tokens, read in one multicall at the same block.
+// The balance of a token that fails the call is nil.
+func (e *EthHandler) FetchTokenBalances(account common.Address, tokens []*Token, callOpts *bind.CallOpts) ([]*big.Int, error) {
+	erc20Abi, err := erc20.Erc20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	multicaller, err := e.NewMulticaller()
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]*MulticallCall, len(tokens))
+	for i, token := range tokens {
+		calls[i] = NewMulticallCall(token.AddressForGeth(), erc20Abi, "balanceOf", account)
+		calls[i].AllowFailure = true
+	}
+
+	results, err := multicaller.Call(callOpts, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]*big.Int, len(tokens))
+	for i, result := range results {
+		if result.Err != nil {
+			fmt.Printf("unable to fetch %v balance: %v\n", tokens[i].Symbol, result.Err)
+			continue
+		}
+		balances[i] = result.Outputs[0].(*big.Int)
+	}
+
+	return balances, nil
+}
+
 func (e *ERC20Handler) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
 	return e.Contract.Allowance(&bind.CallOpts{}, owner, spender)
 }
@@ -100,27 +135,11 @@ func (e *ERC20Handler) validateApproveTx(wallet *Wallet, tx *types.Transaction,
 // To mitigate this, we have to set the allowance to 0 and then set the desired amount afterwards.
 // https://github.com/ethereum/EIPs/issues/20#issuecomment-263524729
 func (e *ERC20Handler) unsafeApprove(wallet *Wallet, spender common.Address, amount *big.Int) error {
-	nonce, err := e.Client.PendingNonceAt(context.Background(), wallet.Address)
+	auth, err := e.TransactOpts(wallet, nil, true)
 	if err != nil {
 		panic(err)
 	}
 
-	chainId := big.NewInt(int64(e.Network.ChainId))
-	auth, err := bind.NewKeyedTransactorWithChainID(wallet.PrivateKey, chainId)
-	if err != nil {
-		panic(err)
-	}
-
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = nil
-	auth.NoSend = false
-
-	// TODO: Get gas estimates from the gasEstimator module
-	auth.GasPrice = nil
-	auth.GasFeeCap = nil
-	auth.GasTipCap = nil
-	auth.GasLimit = uint64(0) // in units
-
 	tx, err := e.Contract.Approve(auth, spender, amount)
 	if err != nil {
 		panic(err)
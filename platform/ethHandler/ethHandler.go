@@ -20,6 +20,10 @@ type EthHandler struct {
 	ProviderProtocol ProviderProtocol
 	ExchangeInfo     *models.Exchange
 	Client           *ethclient.Client
+	TxTracker        *TxTracker // follows the mined transactions through reorgs when set
+
+	// Called with the tokens added to the registry by DiscoverToken when set
+	OnTokenDiscovered func(token *Token)
 }
 
 func NewEthHandler(
@@ -83,6 +87,10 @@ func (e *EthHandler) WaitTxMined(tx *types.Transaction, fromAddress common.Addre
 		panic(err)
 	}
 
+	if e.TxTracker != nil {
+		e.TxTracker.Track(tx, fromAddress, txReceipt)
+	}
+
 	txSuccess := txReceipt.Status == types.ReceiptStatusSuccessful
 	fmt.Println("\n[mined tx receipt]")
 	fmt.Println("status success: ", txSuccess)
@@ -43,6 +43,16 @@ var evmNetworkMap = map[string]*EvmNetwork{
 			Decimals: 18,
 		},
 	},
+	"bsc_mainnet": {
+		Name:      "bsc_mainnet",
+		IsMainnet: true,
+		ChainId:   ChainId(56),
+		NativeCurrency: &NativeCurrency{
+			Name:     "BNB",
+			Symbol:   "BNB",
+			Decimals: 18,
+		},
+	},
 }
 
 func GetEvmNetwork(networkName string) (*EvmNetwork, error) {
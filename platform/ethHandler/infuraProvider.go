@@ -18,10 +18,12 @@ var infuraProviderInst *InfuraProvider
 
 func genEndpointsMap() map[string][]string {
 	endpointsMap := map[string][]string{
-		genEndpointsMapKey(1, Https):      {"https://mainnet.infura.io/v3"},
-		genEndpointsMapKey(1, WebSockets): {"wss://mainnet.infura.io/ws/v3"},
-		genEndpointsMapKey(5, Https):      {"https://goerli.infura.io/v3"},
-		genEndpointsMapKey(5, WebSockets): {"wss://goerli.infura.io/ws/v3"},
+		genEndpointsMapKey(1, Https):       {"https://mainnet.infura.io/v3"},
+		genEndpointsMapKey(1, WebSockets):  {"wss://mainnet.infura.io/ws/v3"},
+		genEndpointsMapKey(5, Https):       {"https://goerli.infura.io/v3"},
+		genEndpointsMapKey(5, WebSockets):  {"wss://goerli.infura.io/ws/v3"},
+		genEndpointsMapKey(56, Https):      {"https://bsc-mainnet.infura.io/v3"},
+		genEndpointsMapKey(56, WebSockets): {"wss://bsc-mainnet.infura.io/ws/v3"},
 	}
 
 	for key, rpcUrls := range endpointsMap {
@@ -0,0 +1,169 @@
+package ethHandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/multicall3"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// Multicall3 is deployed at the same address on every supported chain
+	multicall3Address         = "0xcA11bde05977b3631167028862bE2a173976CA11"
+	defaultMulticallChunkSize = 500 // calls per eth_call, keeps requests under the provider gas and size limits
+)
+
+var ErrMulticallFailed = errors.New("multicall read failed")
+
+// A contract read batched into a multicall
+type MulticallCall struct {
+	Target       common.Address
+	Abi          *abi.ABI
+	Method       string
+	Args         []interface{}
+	AllowFailure bool // a failed call only fails its own result instead of the whole batch
+}
+
+type MulticallResult struct {
+	Outputs []interface{} // unpacked return values
+	Err     error         // set if a call allowed to fail reverted or returned undecodable data
+}
+
+// Aggregates contract reads into eth_calls to Multicall3's aggregate3
+type Multicaller struct {
+	handler   *EthHandler
+	contract  *multicall3.Multicall3Raw
+	ChunkSize int
+}
+
+func GetMulticall3Address() common.Address {
+	return common.HexToAddress(multicall3Address)
+}
+
+func (e *EthHandler) NewMulticaller() (*Multicaller, error) {
+	instance, err := multicall3.NewMulticall3(GetMulticall3Address(), e.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Multicaller{
+		handler:   e,
+		contract:  &multicall3.Multicall3Raw{Contract: instance},
+		ChunkSize: defaultMulticallChunkSize,
+	}, nil
+}
+
+// Returns a new call of a contract method
+func NewMulticallCall(target common.Address, contractAbi *abi.ABI, method string, args ...interface{}) *MulticallCall {
+	return &MulticallCall{
+		Target: target,
+		Abi:    contractAbi,
+		Method: method,
+		Args:   args,
+	}
+}
+
+// Executes the calls and returns their results in order.
+// Calls are split into chunks of ChunkSize calls, every chunk is read at the same block:
+// the block of the call options, or the latest block when none is set.
+// Returns ErrMulticallFailed if a call not allowed to fail reverts.
+func (m *Multicaller) Call(callOpts *bind.CallOpts, calls []*MulticallCall) ([]*MulticallResult, error) {
+	if callOpts == nil {
+		callOpts = &bind.CallOpts{}
+	}
+
+	if callOpts.BlockNumber == nil && len(calls) > m.chunkSize() {
+		ctx := callOpts.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		blockNumber, err := m.handler.Client.BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		pinned := *callOpts
+		pinned.BlockNumber = new(big.Int).SetUint64(blockNumber)
+		callOpts = &pinned
+	}
+
+	results := make([]*MulticallResult, 0, len(calls))
+	for start := 0; start < len(calls); start += m.chunkSize() {
+		end := start + m.chunkSize()
+		if end > len(calls) {
+			end = len(calls)
+		}
+
+		chunkResults, err := m.callChunk(callOpts, calls[start:end])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, chunkResults...)
+	}
+
+	return results, nil
+}
+
+func (m *Multicaller) chunkSize() int {
+	if m.ChunkSize <= 0 {
+		return defaultMulticallChunkSize
+	}
+
+	return m.ChunkSize
+}
+
+func (m *Multicaller) callChunk(callOpts *bind.CallOpts, calls []*MulticallCall) ([]*MulticallResult, error) {
+	aggregateCalls := make([]multicall3.IMulticall3Call3, len(calls))
+	for i, call := range calls {
+		callData, err := call.Abi.Pack(call.Method, call.Args...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to pack %v call to %v: %w", call.Method, call.Target, err)
+		}
+
+		aggregateCalls[i] = multicall3.IMulticall3Call3{
+			Target:       call.Target,
+			AllowFailure: call.AllowFailure,
+			CallData:     callData,
+		}
+	}
+
+	// aggregate3 is payable, it has to be called through the raw contract
+	var out []interface{}
+	err := m.contract.Call(callOpts, &out, "aggregate3", aggregateCalls)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMulticallFailed, err)
+	}
+
+	returnData := *abi.ConvertType(out[0], new([]multicall3.IMulticall3Result)).(*[]multicall3.IMulticall3Result)
+	if len(returnData) != len(calls) {
+		return nil, fmt.Errorf("%w: %v results for %v calls", ErrMulticallFailed, len(returnData), len(calls))
+	}
+
+	results := make([]*MulticallResult, len(calls))
+	for i, call := range calls {
+		result := &MulticallResult{}
+		switch {
+		case !returnData[i].Success:
+			result.Err = fmt.Errorf("%v call to %v reverted", call.Method, call.Target)
+		case len(returnData[i].ReturnData) == 0 && len(call.Abi.Methods[call.Method].Outputs) > 0:
+			// Calls to an address without code succeed with empty return data
+			result.Err = fmt.Errorf("%v call to %v: %w", call.Method, call.Target, bind.ErrNoCode)
+		default:
+			result.Outputs, result.Err = call.Abi.Unpack(call.Method, returnData[i].ReturnData)
+		}
+
+		if result.Err != nil && !call.AllowFailure {
+			return nil, fmt.Errorf("%w: %v", ErrMulticallFailed, result.Err)
+		}
+
+		results[i] = result
+	}
+
+	return results, nil
+}
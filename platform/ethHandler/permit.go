@@ -0,0 +1,187 @@
+package ethHandler
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/erc20Permit"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PERMIT_TYPEHASH is not part of EIP-2612 but most tokens expose it, which tells
+// EIP-2612 permits apart from the DAI permit that has the same getters
+const permitTypeHashAbi = `[` +
+	`{"inputs":[],"name":"PERMIT_TYPEHASH","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"}` +
+	`]`
+
+var permitTypeHash = crypto.Keccak256Hash([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+
+var ErrPermitNotSupported = errors.New("token does not support EIP-2612 permits")
+
+// EIP-2612 approval signed by a token owner, which anyone can submit to the token
+type Permit struct {
+	Token    common.Address
+	Owner    common.Address
+	Spender  common.Address
+	Value    *big.Int
+	Nonce    *big.Int
+	Deadline *big.Int
+	V        uint8
+	R        [32]byte
+	S        [32]byte
+}
+
+// Domain separators of the tokens checked for permit support, nil if a token does not support permits
+var permitDomains = struct {
+	mu         sync.Mutex
+	separators map[string]*common.Hash
+}{separators: make(map[string]*common.Hash)}
+
+// Returns the EIP-712 domain separator of the token.
+// Returns ErrPermitNotSupported if the token does not implement EIP-2612.
+func (e *ERC20Handler) PermitDomainSeparator() (common.Hash, error) {
+	key := genTokenAddressKey(e.Token.ChainId, e.Token.Address)
+
+	permitDomains.mu.Lock()
+	separator, checked := permitDomains.separators[key]
+	permitDomains.mu.Unlock()
+
+	if !checked {
+		var err error
+		separator, err = e.fetchPermitDomainSeparator()
+		if err != nil {
+			return common.Hash{}, err
+		}
+
+		permitDomains.mu.Lock()
+		permitDomains.separators[key] = separator
+		permitDomains.mu.Unlock()
+	}
+
+	if separator == nil {
+		return common.Hash{}, fmt.Errorf("%w: %v", ErrPermitNotSupported, e.Token)
+	}
+
+	return *separator, nil
+}
+
+func (e *ERC20Handler) SupportsPermit() (bool, error) {
+	_, err := e.PermitDomainSeparator()
+	if errors.Is(err, ErrPermitNotSupported) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Detects EIP-2612 from the DOMAIN_SEPARATOR and nonces getters, read in one multicall.
+// Returns a nil separator if the token does not support permits.
+func (e *ERC20Handler) fetchPermitDomainSeparator() (*common.Hash, error) {
+	permitAbi, err := erc20Permit.Erc20PermitMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	typeHashAbi, err := abi.JSON(strings.NewReader(permitTypeHashAbi))
+	if err != nil {
+		return nil, err
+	}
+
+	multicaller, err := e.NewMulticaller()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAddress := e.Token.AddressForGeth()
+	calls := []*MulticallCall{
+		NewMulticallCall(tokenAddress, permitAbi, "DOMAIN_SEPARATOR"),
+		NewMulticallCall(tokenAddress, permitAbi, "nonces", common.Address{}),
+		NewMulticallCall(tokenAddress, &typeHashAbi, "PERMIT_TYPEHASH"),
+	}
+	for _, call := range calls {
+		call.AllowFailure = true
+	}
+
+	results, err := multicaller.Call(&bind.CallOpts{}, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	domainSeparator, nonces, typeHash := results[0], results[1], results[2]
+	if domainSeparator.Err != nil || nonces.Err != nil {
+		return nil, nil
+	}
+
+	if typeHash.Err == nil && common.Hash(typeHash.Outputs[0].([32]byte)) != permitTypeHash {
+		return nil, nil
+	}
+
+	separator := common.Hash(domainSeparator.Outputs[0].([32]byte))
+	return &separator, nil
+}
+
+// Signs a permit allowing a spender to transfer an amount of the wallet's tokens until a deadline.
+// The permit uses the current nonce of the wallet, so it is invalidated by any permit submitted before it.
+// Returns ErrPermitNotSupported if the token does not implement EIP-2612.
+func (e *ERC20Handler) SignPermit(
+	wallet *Wallet,
+	spender common.Address,
+	value *big.Int,
+	deadline time.Time,
+) (*Permit, error) {
+	domainSeparator, err := e.PermitDomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := erc20Permit.NewErc20Permit(e.Token.AddressForGeth(), e.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := instance.Nonces(&bind.CallOpts{}, wallet.Address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch permit nonce of %v: %w", e.Token, err)
+	}
+
+	permit := &Permit{
+		Token:    e.Token.AddressForGeth(),
+		Owner:    wallet.Address,
+		Spender:  spender,
+		Value:    value,
+		Nonce:    nonce,
+		Deadline: big.NewInt(deadline.Unix()),
+	}
+
+	signature, err := wallet.SignTypedData(domainSeparator, permit.structHash())
+	if err != nil {
+		return nil, err
+	}
+
+	copy(permit.R[:], signature[:32])
+	copy(permit.S[:], signature[32:64])
+	permit.V = signature[64]
+
+	return permit, nil
+}
+
+// keccak256(abi.encode(PERMIT_TYPEHASH, owner, spender, value, nonce, deadline))
+func (p *Permit) structHash() common.Hash {
+	return crypto.Keccak256Hash(
+		permitTypeHash.Bytes(),
+		common.LeftPadBytes(p.Owner.Bytes(), 32),
+		common.LeftPadBytes(p.Spender.Bytes(), 32),
+		common.LeftPadBytes(p.Value.Bytes(), 32),
+		common.LeftPadBytes(p.Nonce.Bytes(), 32),
+		common.LeftPadBytes(p.Deadline.Bytes(), 32))
+}
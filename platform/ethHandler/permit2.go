@@ -0,0 +1,301 @@
+package ethHandler
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/permit2"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Permit2 is deployed at the same address on every supported chain
+const permit2Address = "0x000000000022D473030F116dDEE9F6B43aC78BA3"
+
+var (
+	permitDetailsTypeHash = crypto.Keccak256Hash([]byte(
+		"PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"))
+	permitSingleTypeHash = crypto.Keccak256Hash([]byte(
+		"PermitSingle(PermitDetails details,address spender,uint256 sigDeadline)" +
+			"PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"))
+	permitBatchTypeHash = crypto.Keccak256Hash([]byte(
+		"PermitBatch(PermitDetails[] details,address spender,uint256 sigDeadline)" +
+			"PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"))
+
+	maxUint160 = new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 160), common.Big1)
+)
+
+var ErrPermit2AmountTooLarge = errors.New("allowance amount exceeds the uint160 range of Permit2")
+
+// Allowance of a spender over the tokens of an owner, granted through Permit2
+type Permit2Allowance struct {
+	Amount     *big.Int
+	Expiration time.Time
+	Nonce      *big.Int // nonce of the next permit of the owner for the token and spender
+}
+
+type SignedPermitSingle struct {
+	Owner     common.Address
+	Permit    permit2.IAllowanceTransferPermitSingle
+	Signature []byte
+}
+
+type SignedPermitBatch struct {
+	Owner     common.Address
+	Permit    permit2.IAllowanceTransferPermitBatch
+	Signature []byte
+}
+
+// Grants spenders allowances over tokens through Permit2.
+// Every token is approved once for Permit2 with an ERC20 max approval, spenders are then
+// allowed by permits signed for an amount and an expiration, which cost no transaction.
+type Permit2AllowanceManager struct {
+	handler  *EthHandler
+	contract *permit2.Permit2
+
+	// How long signed allowances stay valid after the signature deadline.
+	// Zero expires an allowance with its signature, so that nothing is left to spend after a swap.
+	Expiration time.Duration
+
+	mu              sync.Mutex
+	domainSeparator *common.Hash
+}
+
+func GetPermit2Address() common.Address {
+	return common.HexToAddress(permit2Address)
+}
+
+func (e *EthHandler) NewPermit2AllowanceManager() (*Permit2AllowanceManager, error) {
+	instance, err := permit2.NewPermit2(GetPermit2Address(), e.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Permit2AllowanceManager{
+		handler:  e,
+		contract: instance,
+	}, nil
+}
+
+// Approves Permit2 to spend the token of the wallet if its ERC20 allowance is below an amount.
+// Permit2 is given the max allowance so that the token is only approved once.
+func (m *Permit2AllowanceManager) EnsureApproved(wallet *Wallet, token *Token, amount *big.Int) error {
+	tokenHandler, err := NewERC20Handler(m.handler, token)
+	if err != nil {
+		return err
+	}
+
+	allowance, err := tokenHandler.Allowance(wallet.Address, GetPermit2Address())
+	if err != nil {
+		return err
+	}
+
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+
+	return tokenHandler.MaxApprove(wallet, GetPermit2Address(), false)
+}
+
+// Returns the Permit2 allowance of a spender over the tokens of an owner
+func (m *Permit2AllowanceManager) Allowance(owner common.Address, token *Token, spender common.Address) (*Permit2Allowance, error) {
+	allowance, err := m.contract.Allowance(&bind.CallOpts{}, owner, token.AddressForGeth(), spender)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch Permit2 allowance of %v: %w", token, err)
+	}
+
+	result := &Permit2Allowance{
+		Amount:     allowance.Amount,
+		Expiration: time.Unix(allowance.Expiration.Int64(), 0),
+		Nonce:      allowance.Nonce,
+	}
+
+	return result, nil
+}
+
+// Returns a permit allowing a spender to transfer an amount of the wallet's tokens until a deadline,
+// or nil if the current allowance of the spender already covers it
+func (m *Permit2AllowanceManager) PermitFor(
+	wallet *Wallet,
+	token *Token,
+	spender common.Address,
+	amount *big.Int,
+	deadline time.Time,
+) (*SignedPermitSingle, error) {
+	allowance, err := m.Allowance(wallet.Address, token, spender)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowance.Amount.Cmp(amount) >= 0 && !allowance.Expiration.Before(deadline) {
+		return nil, nil
+	}
+
+	details := permit2.IAllowanceTransferPermitDetails{
+		Token:      token.AddressForGeth(),
+		Amount:     amount,
+		Expiration: big.NewInt(deadline.Add(m.Expiration).Unix()),
+		Nonce:      allowance.Nonce,
+	}
+
+	return m.SignPermitSingle(wallet, details, spender, deadline)
+}
+
+// Signs the allowance of a spender over one token.
+// The permit has to be submitted before the signature deadline.
+func (m *Permit2AllowanceManager) SignPermitSingle(
+	wallet *Wallet,
+	details permit2.IAllowanceTransferPermitDetails,
+	spender common.Address,
+	sigDeadline time.Time,
+) (*SignedPermitSingle, error) {
+	if details.Amount.Cmp(maxUint160) > 0 {
+		return nil, fmt.Errorf("%w: %v", ErrPermit2AmountTooLarge, details.Amount)
+	}
+
+	permit := permit2.IAllowanceTransferPermitSingle{
+		Details:     details,
+		Spender:     spender,
+		SigDeadline: big.NewInt(sigDeadline.Unix()),
+	}
+
+	structHash := crypto.Keccak256Hash(
+		permitSingleTypeHash.Bytes(),
+		hashPermitDetails(details).Bytes(),
+		common.LeftPadBytes(spender.Bytes(), 32),
+		common.LeftPadBytes(permit.SigDeadline.Bytes(), 32))
+
+	signature, err := m.sign(wallet, structHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedPermitSingle{Owner: wallet.Address, Permit: permit, Signature: signature}, nil
+}
+
+// Signs the allowances of a spender over several tokens with one signature.
+// The nonce of every token is read at the same block.
+func (m *Permit2AllowanceManager) SignPermitBatch(
+	wallet *Wallet,
+	tokens []*Token,
+	amounts []*big.Int,
+	spender common.Address,
+	sigDeadline time.Time,
+) (*SignedPermitBatch, error) {
+	if len(tokens) != len(amounts) {
+		return nil, errors.New("a batch permit needs one amount per token")
+	}
+
+	nonces, err := m.fetchNonces(wallet.Address, tokens, spender)
+	if err != nil {
+		return nil, err
+	}
+
+	expiration := big.NewInt(sigDeadline.Add(m.Expiration).Unix())
+	permit := permit2.IAllowanceTransferPermitBatch{
+		Details:     make([]permit2.IAllowanceTransferPermitDetails, len(tokens)),
+		Spender:     spender,
+		SigDeadline: big.NewInt(sigDeadline.Unix()),
+	}
+
+	// keccak256(abi.encodePacked(detailsHashes))
+	var detailsHashes []byte
+	for i, token := range tokens {
+		if amounts[i].Cmp(maxUint160) > 0 {
+			return nil, fmt.Errorf("%w: %v %v", ErrPermit2AmountTooLarge, amounts[i], token)
+		}
+
+		permit.Details[i] = permit2.IAllowanceTransferPermitDetails{
+			Token:      token.AddressForGeth(),
+			Amount:     amounts[i],
+			Expiration: expiration,
+			Nonce:      nonces[i],
+		}
+		detailsHashes = append(detailsHashes, hashPermitDetails(permit.Details[i]).Bytes()...)
+	}
+
+	structHash := crypto.Keccak256Hash(
+		permitBatchTypeHash.Bytes(),
+		crypto.Keccak256(detailsHashes),
+		common.LeftPadBytes(spender.Bytes(), 32),
+		common.LeftPadBytes(permit.SigDeadline.Bytes(), 32))
+
+	signature, err := m.sign(wallet, structHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedPermitBatch{Owner: wallet.Address, Permit: permit, Signature: signature}, nil
+}
+
+// Reads the permit nonces of a spender over several tokens in one multicall
+func (m *Permit2AllowanceManager) fetchNonces(owner common.Address, tokens []*Token, spender common.Address) ([]*big.Int, error) {
+	permit2Abi, err := permit2.Permit2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	multicaller, err := m.handler.NewMulticaller()
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]*MulticallCall, len(tokens))
+	for i, token := range tokens {
+		calls[i] = NewMulticallCall(GetPermit2Address(), permit2Abi, "allowance", owner, token.AddressForGeth(), spender)
+	}
+
+	results, err := multicaller.Call(&bind.CallOpts{}, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	nonces := make([]*big.Int, len(tokens))
+	for i, result := range results {
+		nonces[i] = result.Outputs[2].(*big.Int)
+	}
+
+	return nonces, nil
+}
+
+func (m *Permit2AllowanceManager) sign(wallet *Wallet, structHash common.Hash) ([]byte, error) {
+	domainSeparator, err := m.fetchDomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet.SignTypedData(domainSeparator, structHash)
+}
+
+// The domain separator only depends on the chain, it is read once
+func (m *Permit2AllowanceManager) fetchDomainSeparator() (common.Hash, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.domainSeparator != nil {
+		return *m.domainSeparator, nil
+	}
+
+	separator, err := m.contract.DOMAINSEPARATOR(&bind.CallOpts{})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("unable to fetch Permit2 domain separator: %w", err)
+	}
+
+	domainSeparator := common.Hash(separator)
+	m.domainSeparator = &domainSeparator
+	return domainSeparator, nil
+}
+
+// keccak256(abi.encode(PERMIT_DETAILS_TYPEHASH, details))
+func hashPermitDetails(details permit2.IAllowanceTransferPermitDetails) common.Hash {
+	return crypto.Keccak256Hash(
+		permitDetailsTypeHash.Bytes(),
+		common.LeftPadBytes(details.Token.Bytes(), 32),
+		common.LeftPadBytes(details.Amount.Bytes(), 32),
+		common.LeftPadBytes(details.Expiration.Bytes(), 32),
+		common.LeftPadBytes(details.Nonce.Bytes(), 32))
+}
@@ -1,8 +1,12 @@
 package ethHandler
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
+	"sync"
 
+	"github.com/Opulentia-Trading/Arbitrage/platform/assetRegistry"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -22,12 +26,14 @@ func (t TokenType) String() string {
 }
 
 type Token struct {
-	ChainId  ChainId
-	Type     TokenType
-	Address  string
-	Name     string
-	Symbol   string
-	Decimals uint8
+	ChainId        ChainId
+	Type           TokenType
+	Address        string
+	Name           string
+	Symbol         string
+	Decimals       uint8
+	TransferFeeBps uint // fee charged by the token on transfers, in basis points
+	Discovered     bool // read from the chain, TransferFeeBps is not known
 }
 
 // TODO: Parse from json or config file
@@ -96,13 +102,48 @@ var tokensMap = map[string]*Token{
 		Symbol:   "LINK",
 		Decimals: 18,
 	},
+	genTokensMapKey(56, "WBNB"): {
+		ChainId:  56,
+		Type:     ERC20,
+		Address:  "0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095c",
+		Name:     "Wrapped BNB",
+		Symbol:   "WBNB",
+		Decimals: 18,
+	},
+	genTokensMapKey(56, "USDT"): {
+		ChainId:  56,
+		Type:     ERC20,
+		Address:  "0x55d398326f99059fF775485246999027B3197955",
+		Name:     "Tether USD",
+		Symbol:   "USDT",
+		Decimals: 18,
+	},
+}
+
+var (
+	tokensMu        sync.RWMutex
+	tokensByAddress = make(map[string]*Token) // every known token, including the ones discovered on chain
+)
+
+func init() {
+	for _, token := range tokensMap {
+		tokensByAddress[genTokenAddressKey(token.ChainId, token.Address)] = token
+		assetRegistry.RegisterToken(uint(token.ChainId), token.Address, token.AssetId())
+	}
 }
 
 func genTokensMapKey(chainId ChainId, symbol string) string {
 	return fmt.Sprintf("%v|%v", chainId, symbol)
 }
 
+func genTokenAddressKey(chainId ChainId, address string) string {
+	return fmt.Sprintf("%v|%v", chainId, strings.ToLower(address))
+}
+
 func GetToken(chainId ChainId, symbol string) (*Token, error) {
+	tokensMu.RLock()
+	defer tokensMu.RUnlock()
+
 	key := genTokensMapKey(chainId, symbol)
 	token, tokenFound := tokensMap[key]
 	if !tokenFound {
@@ -112,6 +153,107 @@ func GetToken(chainId ChainId, symbol string) (*Token, error) {
 	return token, nil
 }
 
+func GetTokenByAddress(chainId ChainId, address string) (*Token, error) {
+	tokensMu.RLock()
+	defer tokensMu.RUnlock()
+
+	token, tokenFound := tokensByAddress[genTokenAddressKey(chainId, address)]
+	if !tokenFound {
+		return nil, fmt.Errorf("unknown token with chainId=%v address=%v", chainId, address)
+	}
+
+	return token, nil
+}
+
+// Returns a known token from its symbol or its address
+func LookupToken(chainId ChainId, symbolOrAddress string) (*Token, error) {
+	if common.IsHexAddress(symbolOrAddress) {
+		return GetTokenByAddress(chainId, symbolOrAddress)
+	}
+
+	return GetToken(chainId, symbolOrAddress)
+}
+
+// Adds a token to the registry and returns the registered token.
+// A token already known at the same address is returned instead. The token can only be looked up
+// by symbol if no other token of the chain uses its symbol, so that a token cannot shadow another one.
+func RegisterToken(token *Token) *Token {
+	tokensMu.Lock()
+	defer tokensMu.Unlock()
+
+	addressKey := genTokenAddressKey(token.ChainId, token.Address)
+	if known, found := tokensByAddress[addressKey]; found {
+		return known
+	}
+	tokensByAddress[addressKey] = token
+
+	symbolKey := genTokensMapKey(token.ChainId, token.Symbol)
+	if _, found := tokensMap[symbolKey]; !found {
+		tokensMap[symbolKey] = token
+		assetRegistry.RegisterToken(uint(token.ChainId), token.Address, token.AssetId())
+	}
+
+	return token
+}
+
+// Removes a token added by RegisterToken
+func UnregisterToken(token *Token) {
+	tokensMu.Lock()
+	defer tokensMu.Unlock()
+
+	addressKey := genTokenAddressKey(token.ChainId, token.Address)
+	if tokensByAddress[addressKey] != token {
+		return
+	}
+	delete(tokensByAddress, addressKey)
+
+	symbolKey := genTokensMapKey(token.ChainId, token.Symbol)
+	if tokensMap[symbolKey] == token {
+		delete(tokensMap, symbolKey)
+		assetRegistry.UnregisterToken(uint(token.ChainId), token.Address)
+	}
+}
+
+// Returns the tokens of the registry on a chain
+func GetChainTokens(chainId ChainId) []*Token {
+	tokensMu.RLock()
+	defer tokensMu.RUnlock()
+
+	var result []*Token
+	for _, token := range tokensMap {
+		if token.ChainId == chainId {
+			result = append(result, token)
+		}
+	}
+
+	return result
+}
+
+// Returns the token representing a canonical asset on a chain.
+// Native currencies resolve to their wrapped token (ETH => WETH).
+func GetTokenForAsset(chainId ChainId, asset string) (*Token, error) {
+	address, _, err := assetRegistry.ChainToken(uint(chainId), assetRegistry.AssetId(asset), assetRegistry.Wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetTokenByAddress(chainId, address)
+}
+
+// Sorts two token addresses the way Uniswap pairs and pools order their tokens
+func SortTokens(tokenA common.Address, tokenB common.Address) (common.Address, common.Address) {
+	if bytes.Compare(tokenA.Bytes(), tokenB.Bytes()) < 0 {
+		return tokenA, tokenB
+	}
+
+	return tokenB, tokenA
+}
+
+// Returns the canonical asset id of the token
+func (t *Token) AssetId() assetRegistry.AssetId {
+	return assetRegistry.AssetId(t.Symbol)
+}
+
 // Returns the token address in a byte array format used by the Go Ethereum package
 func (t *Token) AddressForGeth() common.Address {
 	return common.HexToAddress(t.Address)
@@ -0,0 +1,128 @@
+package ethHandler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/erc20"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Legacy tokens such as MKR return their name and symbol as bytes32 instead of string
+const bytes32MetadataAbi = `[` +
+	`{"inputs":[],"name":"name","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"},` +
+	`{"inputs":[],"name":"symbol","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"}` +
+	`]`
+
+var ErrNotERC20 = errors.New("contract is not an ERC20 token")
+
+// Returns the token at an address, reading its name, symbol and decimals from the chain
+// if it is not in the registry yet. Discovered tokens are added to the registry.
+func (e *EthHandler) DiscoverToken(address string) (*Token, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid token address: %v", address)
+	}
+
+	if token, err := GetTokenByAddress(e.Network.ChainId, address); err == nil {
+		return token, nil
+	}
+
+	token, err := e.fetchTokenMetadata(common.HexToAddress(address))
+	if err != nil {
+		return nil, err
+	}
+
+	registered := RegisterToken(token)
+	if registered == token && e.OnTokenDiscovered != nil {
+		e.OnTokenDiscovered(token)
+	}
+
+	return registered, nil
+}
+
+// Returns a token from its symbol or its address.
+// Unknown addresses are discovered from the chain, unknown symbols fail.
+func (e *EthHandler) ResolveToken(symbolOrAddress string) (*Token, error) {
+	if common.IsHexAddress(symbolOrAddress) {
+		return e.DiscoverToken(symbolOrAddress)
+	}
+
+	return GetToken(e.Network.ChainId, symbolOrAddress)
+}
+
+// Reads the metadata of a token in one multicall.
+// name and symbol are read both as string and as bytes32, the string result is used when it decodes.
+func (e *EthHandler) fetchTokenMetadata(address common.Address) (*Token, error) {
+	erc20Abi, err := erc20.Erc20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	legacyAbi, err := abi.JSON(strings.NewReader(bytes32MetadataAbi))
+	if err != nil {
+		return nil, err
+	}
+
+	multicaller, err := e.NewMulticaller()
+	if err != nil {
+		return nil, err
+	}
+
+	calls := []*MulticallCall{
+		NewMulticallCall(address, erc20Abi, "name"),
+		NewMulticallCall(address, erc20Abi, "symbol"),
+		NewMulticallCall(address, &legacyAbi, "name"),
+		NewMulticallCall(address, &legacyAbi, "symbol"),
+		NewMulticallCall(address, erc20Abi, "decimals"),
+	}
+	for _, call := range calls {
+		call.AllowFailure = true
+	}
+
+	results, err := multicaller.Call(&bind.CallOpts{}, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	decimals := results[4]
+	if decimals.Err != nil {
+		return nil, fmt.Errorf("%w: %v: %v", ErrNotERC20, address, decimals.Err)
+	}
+
+	// name is optional in the ERC20 standard
+	name, _ := metadataString(results[0], results[2])
+	symbol, ok := metadataString(results[1], results[3])
+	if !ok || symbol == "" {
+		return nil, fmt.Errorf("%w: %v has no symbol", ErrNotERC20, address)
+	}
+
+	token := &Token{
+		ChainId:    e.Network.ChainId,
+		Type:       ERC20,
+		Address:    address.Hex(),
+		Name:       name,
+		Symbol:     symbol,
+		Decimals:   decimals.Outputs[0].(uint8),
+		Discovered: true,
+	}
+
+	return token, nil
+}
+
+// Returns the string of a string result, or of a bytes32 result if the string failed to decode
+func metadataString(stringResult *MulticallResult, bytes32Result *MulticallResult) (string, bool) {
+	if stringResult.Err == nil {
+		return strings.TrimSpace(stringResult.Outputs[0].(string)), true
+	}
+
+	if bytes32Result.Err == nil {
+		value := bytes32Result.Outputs[0].([32]byte)
+		return strings.TrimSpace(string(bytes.TrimRight(value[:], "\x00"))), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,234 @@
+package ethHandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const DefaultTxConfirmations = 12
+
+type TxStatus uint
+
+const (
+	TxMined      TxStatus = iota
+	TxReorged             // the block including the tx was removed from the chain
+	TxReincluded          // a reorged tx was included in a block of the new branch
+	TxConfirmed           // the tx is deep enough to be considered final
+	TxDropped             // a reorged tx was not included again within MaxReorgDepth blocks
+)
+
+func (s TxStatus) String() string {
+	return [...]string{
+		"Mined",
+		"Reorged",
+		"Reincluded",
+		"Confirmed",
+		"Dropped"}[s]
+}
+
+// A mined transaction followed until it is confirmed
+type TrackedTx struct {
+	Hash        common.Hash
+	From        common.Address
+	Status      TxStatus
+	Success     bool // receipt status of the latest inclusion
+	BlockNumber uint64
+	BlockHash   common.Hash
+	ReorgedAt   uint64 // head block when the tx was found reorged
+}
+
+// Change of status of a tracked transaction
+type TxEvent struct {
+	Tx            TrackedTx   // state of the tx after the change
+	PrevBlockHash common.Hash // block the tx was included in before the change
+}
+
+// Source of the receipts of the tracked transactions, the client of the handler
+type receiptSource interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// Follows our mined transactions through reorgs.
+// The receipts of the transactions included after the fork point of a reorg are re-checked
+// and transactions are reported as reorged, re-included, dropped or confirmed.
+// A transaction is only confirmed if its receipt is still in the block it was tracked in,
+// so reorgs missed by the block subscription are caught before the confirmation.
+// Tracking is opt-in: set EthHandler.TxTracker and follow the blocks with Run.
+type TxTracker struct {
+	handler       *EthHandler
+	receipts      receiptSource
+	Confirmations uint64
+	OnEvent       func(event *TxEvent) // receives the status changes of the tracked transactions when set
+
+	mu  sync.Mutex
+	txs map[common.Hash]*TrackedTx
+}
+
+func NewTxTracker(handler *EthHandler, confirmations uint64) *TxTracker {
+	tracker := newTxTracker(handler.Client, confirmations)
+	tracker.handler = handler
+	return tracker
+}
+
+func newTxTracker(receipts receiptSource, confirmations uint64) *TxTracker {
+	return &TxTracker{
+		receipts:      receipts,
+		Confirmations: confirmations,
+		txs:           make(map[common.Hash]*TrackedTx),
+	}
+}
+
+// Starts tracking a mined transaction
+func (t *TxTracker) Track(tx *types.Transaction, from common.Address, receipt *types.Receipt) *TxEvent {
+	tracked := &TrackedTx{
+		Hash:        tx.Hash(),
+		From:        from,
+		Status:      TxMined,
+		Success:     receipt.Status == types.ReceiptStatusSuccessful,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		BlockHash:   receipt.BlockHash,
+	}
+
+	t.mu.Lock()
+	t.txs[tracked.Hash] = tracked
+	t.mu.Unlock()
+
+	return &TxEvent{Tx: *tracked}
+}
+
+// Processes the new blocks until the context is cancelled.
+// The block subscription is restarted if it fails.
+func (t *TxTracker) Run(ctx context.Context) error {
+	for {
+		err := t.handler.ForEachBlock(ctx, func(block *BlockEvent) error {
+			events, err := t.ProcessBlock(ctx, block)
+			for _, event := range events {
+				t.emit(event)
+			}
+			return err
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		fmt.Printf("tx tracker: %v\n", err)
+		select {
+		case <-time.After(headPollInterval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (t *TxTracker) emit(event *TxEvent) {
+	fmt.Printf("tx %v: %v in block %v (%v)\n", event.Tx.Hash, event.Tx.Status, event.Tx.BlockNumber, event.Tx.BlockHash)
+
+	if t.OnEvent != nil {
+		t.OnEvent(event)
+	}
+}
+
+// Returns the transactions not confirmed or dropped yet
+func (t *TxTracker) Tracked() []TrackedTx {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]TrackedTx, 0, len(t.txs))
+	for _, tracked := range t.txs {
+		result = append(result, *tracked)
+	}
+
+	return result
+}
+
+// Updates the tracked transactions with a new block of the canonical chain.
+// Called by the tracker for every block, in order, and returns the status changes of the block.
+func (t *TxTracker) ProcessBlock(ctx context.Context, block *BlockEvent) ([]*TxEvent, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var events []*TxEvent
+	for hash, tracked := range t.txs {
+		reorged := block.Reorg != nil && tracked.BlockNumber > block.Reorg.ForkPoint.Number.Uint64()
+		if tracked.Status == TxReorged || reorged {
+			txEvents, err := t.recheckReceipt(ctx, tracked, block)
+			if err != nil {
+				return events, err
+			}
+			events = append(events, txEvents...)
+
+			if tracked.Status == TxDropped {
+				delete(t.txs, hash)
+			}
+			continue
+		}
+
+		if block.Number()+1 >= tracked.BlockNumber+t.Confirmations {
+			// A reorg the subscription did not see, e.g. before the tx was tracked,
+			// moved or dropped the tx if its receipt is not in the same block anymore
+			txEvents, err := t.recheckReceipt(ctx, tracked, block)
+			if err != nil {
+				return events, err
+			}
+			if len(txEvents) > 0 {
+				events = append(events, txEvents...)
+				continue
+			}
+
+			tracked.Status = TxConfirmed
+			events = append(events, &TxEvent{Tx: *tracked, PrevBlockHash: tracked.BlockHash})
+			delete(t.txs, hash)
+		}
+	}
+
+	return events, nil
+}
+
+// Must be called with the lock held
+func (t *TxTracker) recheckReceipt(ctx context.Context, tracked *TrackedTx, block *BlockEvent) ([]*TxEvent, error) {
+	prevBlockHash := tracked.BlockHash
+	receipt, err := t.receipts.TransactionReceipt(ctx, tracked.Hash)
+	if errors.Is(err, ethereum.NotFound) {
+		switch {
+		case tracked.Status != TxReorged:
+			tracked.Status = TxReorged
+			tracked.ReorgedAt = block.Number()
+		case block.Number() >= tracked.ReorgedAt+MaxReorgDepth:
+			tracked.Status = TxDropped
+		default:
+			return nil, nil
+		}
+
+		return []*TxEvent{{Tx: *tracked, PrevBlockHash: prevBlockHash}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Still included in the same block, the reorg happened above it
+	if tracked.Status != TxReorged && receipt.BlockHash == tracked.BlockHash {
+		return nil, nil
+	}
+
+	var events []*TxEvent
+	if tracked.Status != TxReorged {
+		tracked.Status = TxReorged
+		tracked.ReorgedAt = block.Number()
+		events = append(events, &TxEvent{Tx: *tracked, PrevBlockHash: prevBlockHash})
+	}
+
+	tracked.Status = TxReincluded
+	tracked.Success = receipt.Status == types.ReceiptStatusSuccessful
+	tracked.BlockNumber = receipt.BlockNumber.Uint64()
+	tracked.BlockHash = receipt.BlockHash
+	events = append(events, &TxEvent{Tx: *tracked, PrevBlockHash: prevBlockHash})
+
+	return events, nil
+}
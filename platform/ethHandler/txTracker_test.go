@@ -0,0 +1,170 @@
+package ethHandler
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Receipts of the canonical chain, a missing receipt is a tx not included in it
+type fakeReceipts map[common.Hash]*types.Receipt
+
+func (f fakeReceipts) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if receipt, found := f[txHash]; found {
+		return receipt, nil
+	}
+
+	return nil, ethereum.NotFound
+}
+
+func testReceipt(blockNumber uint64, blockHash string) *types.Receipt {
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+		BlockHash:   common.HexToHash(blockHash),
+	}
+}
+
+func testBlock(number uint64) *BlockEvent {
+	return &BlockEvent{Header: &types.Header{Number: new(big.Int).SetUint64(number)}}
+}
+
+func testReorgBlock(number uint64, forkPoint uint64) *BlockEvent {
+	block := testBlock(number)
+	block.Reorg = &Reorg{ForkPoint: &types.Header{Number: new(big.Int).SetUint64(forkPoint)}}
+	return block
+}
+
+// A step of the chain, with the receipt of the tx on the canonical chain at that block
+type trackerStep struct {
+	block   *BlockEvent
+	receipt *types.Receipt // nil if the tx is not included
+	want    []TxStatus     // status changes reported for the block
+}
+
+func TestTxTracker(t *testing.T) {
+	const confirmations = 3
+	mined := testReceipt(100, "0xa1")
+
+	tests := []struct {
+		name  string
+		steps []trackerStep
+	}{
+		{
+			name: "mined then confirmed",
+			steps: []trackerStep{
+				{block: testBlock(101), receipt: mined},
+				{block: testBlock(102), receipt: mined, want: []TxStatus{TxConfirmed}},
+			},
+		},
+		{
+			name: "reorg above the tx",
+			steps: []trackerStep{
+				{block: testReorgBlock(101, 100), receipt: mined},
+				{block: testBlock(102), receipt: mined, want: []TxStatus{TxConfirmed}},
+			},
+		},
+		{
+			name: "reorged then re-included then confirmed",
+			steps: []trackerStep{
+				{block: testReorgBlock(100, 99), want: []TxStatus{TxReorged}},
+				{block: testBlock(101), receipt: testReceipt(101, "0xb2"), want: []TxStatus{TxReincluded}},
+				{block: testBlock(102), receipt: testReceipt(101, "0xb2")},
+				{block: testBlock(103), receipt: testReceipt(101, "0xb2"), want: []TxStatus{TxConfirmed}},
+			},
+		},
+		{
+			name: "re-included by the reorg",
+			steps: []trackerStep{
+				{block: testReorgBlock(101, 99), receipt: testReceipt(101, "0xb2"), want: []TxStatus{TxReorged, TxReincluded}},
+				{block: testBlock(103), receipt: testReceipt(101, "0xb2"), want: []TxStatus{TxConfirmed}},
+			},
+		},
+		{
+			name: "reorged then dropped",
+			steps: []trackerStep{
+				{block: testReorgBlock(100, 99), want: []TxStatus{TxReorged}},
+				{block: testBlock(100 + MaxReorgDepth - 1)},
+				{block: testBlock(100 + MaxReorgDepth), want: []TxStatus{TxDropped}},
+			},
+		},
+		{
+			name: "reorg missed by the subscription moves the tx",
+			steps: []trackerStep{
+				{block: testBlock(102), receipt: testReceipt(101, "0xb2"), want: []TxStatus{TxReorged, TxReincluded}},
+				{block: testBlock(103), receipt: testReceipt(101, "0xb2"), want: []TxStatus{TxConfirmed}},
+			},
+		},
+		{
+			name: "reorg missed by the subscription drops the tx",
+			steps: []trackerStep{
+				{block: testBlock(102), want: []TxStatus{TxReorged}},
+				{block: testBlock(103)},
+				{block: testBlock(102 + MaxReorgDepth), want: []TxStatus{TxDropped}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			receipts := fakeReceipts{}
+			tracker := newTxTracker(receipts, confirmations)
+
+			tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+			if event := tracker.Track(tx, common.Address{}, mined); event.Tx.Status != TxMined {
+				t.Fatalf("got %v, want %v", event.Tx.Status, TxMined)
+			}
+
+			for _, step := range test.steps {
+				delete(receipts, tx.Hash())
+				if step.receipt != nil {
+					receipts[tx.Hash()] = step.receipt
+				}
+
+				events, err := tracker.ProcessBlock(context.Background(), step.block)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var got []TxStatus
+				for _, event := range events {
+					got = append(got, event.Tx.Status)
+				}
+				if !reflect.DeepEqual(got, step.want) {
+					t.Fatalf("block %v: got %v, want %v", step.block.Number(), got, step.want)
+				}
+			}
+
+			if tracked := tracker.Tracked(); len(tracked) != 0 {
+				t.Errorf("still tracking %v", tracked)
+			}
+		})
+	}
+}
+
+func TestTxTrackerEventBlockHashes(t *testing.T) {
+	receipts := fakeReceipts{}
+	tracker := newTxTracker(receipts, DefaultTxConfirmations)
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+	tracker.Track(tx, common.Address{}, testReceipt(100, "0xa1"))
+	receipts[tx.Hash()] = testReceipt(101, "0xb2")
+
+	events, err := tracker.ProcessBlock(context.Background(), testReorgBlock(101, 99))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %v events, want 2", len(events))
+	}
+
+	reincluded := events[1]
+	if reincluded.PrevBlockHash != common.HexToHash("0xa1") || reincluded.Tx.BlockHash != common.HexToHash("0xb2") || reincluded.Tx.BlockNumber != 101 {
+		t.Errorf("got %+v", reincluded)
+	}
+}
@@ -0,0 +1,112 @@
+package uniswapV2Handler
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	liquidityTokenName     = "Uniswap V2"
+	liquidityTokenSymbol   = "UNI-V2"
+	liquidityTokenDecimals = 18
+)
+
+// Returns the liquidity token of a pair. Pairs implement EIP-2612 for their liquidity tokens.
+func (p *PairWrapper) LiquidityToken() *ethHandler.Token {
+	return &ethHandler.Token{
+		ChainId:  p.ChainId,
+		Type:     ethHandler.ERC20,
+		Address:  p.PairAddress,
+		Name:     liquidityTokenName,
+		Symbol:   liquidityTokenSymbol,
+		Decimals: liquidityTokenDecimals,
+	}
+}
+
+// Burns liquidity tokens of a pair and sends the withdrawn tokens to the wallet.
+// The router is allowed to burn the liquidity tokens by a permit signed for the pair
+// instead of an approve transaction, so the removal takes a single transaction.
+// The transaction is only broadcast if SendSwapTx is set.
+func (h *UniswapV2Handler) RemoveLiquidityWithPermit(
+	wallet *ethHandler.Wallet,
+	base string,
+	quote string,
+	liquidity *big.Int,
+	amountBaseMin *big.Int,
+	amountQuoteMin *big.Int,
+	deadline time.Time,
+) (*types.Transaction, error) {
+	pair, err := h.GetPair(base, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	baseToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, base)
+	if err != nil {
+		return nil, err
+	}
+
+	quoteToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	liquidityHandler, err := ethHandler.NewERC20Handler(h.EthHandler, pair.LiquidityToken())
+	if err != nil {
+		return nil, err
+	}
+
+	permit, err := liquidityHandler.SignPermit(wallet, h.Config.RouterAddressForGeth(), liquidity, deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	routerInstance, err := h.getRouter02Instance()
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := h.TransactOpts(wallet, nil, h.SendSwapTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The permit is for the exact liquidity, approveMax would require a permit of the max amount
+	tx, err := routerInstance.RemoveLiquidityWithPermit(
+		auth,
+		baseToken.AddressForGeth(),
+		quoteToken.AddressForGeth(),
+		liquidity,
+		amountBaseMin,
+		amountQuoteMin,
+		wallet.Address,
+		permit.Deadline,
+		false,
+		permit.V,
+		permit.R,
+		permit.S)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("\n[[ %v remove liquidity tx ]]\n", pair.Symbol())
+	fmt.Printf("tx hash: %s\n", tx.Hash())
+	fmt.Printf("gas priority fee: %v\n", tx.GasTipCap())
+	fmt.Printf("gas max fee: %v\n", tx.GasFeeCap())
+	fmt.Printf("gas limit: %v\n", tx.Gas())
+	if auth.NoSend {
+		fmt.Println("Note: transaction not sent on blockchain")
+		return tx, nil
+	}
+
+	_, err = h.WaitTxMined(tx, wallet.Address, txMineWaitTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,82 @@
+package uniswapV2Handler
+
+import (
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+)
+
+// Builds a swap order from fresh reserves.
+// An exact input buy spends the quote amount currently required to receive the base quantity
+// and an exact output buy receives exactly the base quantity. A sell spends the base quantity.
+// The minimum output or maximum input is bounded by the slippage.
+func (h *UniswapV2Handler) BuildOrder(params *ethHandler.OrderParams) (models.Order, error) {
+	var order models.Order
+	if err := params.Validate(); err != nil {
+		return order, err
+	}
+
+	baseToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, params.Base)
+	if err != nil {
+		return order, err
+	}
+
+	quoteToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, params.Quote)
+	if err != nil {
+		return order, err
+	}
+
+	quantity, err := baseToken.ParseAmount(params.Quantity)
+	if err != nil {
+		return order, err
+	}
+
+	path, _, err := h.getOrderPath(baseToken, quoteToken, params.Action)
+	if err != nil {
+		return order, err
+	}
+
+	// A buy is sized by the quote amount required to receive the base quantity
+	quoteKind := models.ExactInput
+	if params.Action == models.BuyLongSpot {
+		quoteKind = models.ExactOutput
+	}
+
+	quote, err := h.FindBestPath(path[0], path[len(path)-1], quantity, quoteKind)
+	if err != nil {
+		return order, err
+	}
+
+	hasTransferFee, err := h.pathHasTransferFee(quote.Path)
+	if err != nil {
+		return order, err
+	}
+
+	if hasTransferFee && params.SwapKind == models.ExactOutput {
+		return order, ErrExactOutputTransferFee
+	}
+
+	price := ethHandler.ExecutionPrice(baseToken, quantity, quoteToken, quote.AmountOut)
+	if params.Action == models.BuyLongSpot {
+		price = ethHandler.ExecutionPrice(baseToken, quantity, quoteToken, quote.AmountIn)
+	}
+
+	order = models.Order{
+		Exchange:         h.ExchangeInfo,
+		Base:             params.Base,
+		Quote:            params.Quote,
+		Action:           params.Action,
+		Price:            price,
+		Quantity:         quantity,
+		SwapKind:         params.SwapKind,
+		LiqPoolAmountIn:  quote.AmountIn,
+		LiqPoolAmountOut: ethHandler.AmountOutMin(quote.AmountOut, params.SlippageBps),
+		Deadline:         params.Deadline,
+	}
+
+	if params.SwapKind == models.ExactOutput {
+		order.LiqPoolAmountIn = ethHandler.AmountInMax(quote.AmountIn, params.SlippageBps)
+		order.LiqPoolAmountOut = quote.AmountOut
+	}
+
+	return order, nil
+}
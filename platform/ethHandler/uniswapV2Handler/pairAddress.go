@@ -0,0 +1,27 @@
+package uniswapV2Handler
+
+import (
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Computes the address of the pair of two tokens without querying the factory.
+// Pairs are deployed with CREATE2 using keccak256(token0, token1) as the salt:
+// address = keccak256(0xff ++ factory ++ salt ++ initCodeHash)[12:]
+// Note: the pair is not guaranteed to be deployed at the returned address.
+func ComputePairAddress(
+	factoryAddress common.Address,
+	initCodeHash common.Hash,
+	tokenA common.Address,
+	tokenB common.Address,
+) common.Address {
+	token0, token1 := ethHandler.SortTokens(tokenA, tokenB)
+	salt := crypto.Keccak256Hash(token0.Bytes(), token1.Bytes())
+	return crypto.CreateAddress2(factoryAddress, salt, initCodeHash.Bytes())
+}
+
+// Computes the address of the pair of two tokens on a Uniswap V2 platform
+func (c *UniswapV2Config) ComputePairAddress(tokenA common.Address, tokenB common.Address) common.Address {
+	return ComputePairAddress(c.FactoryAddressForGeth(), c.InitCodeHashForGeth(), tokenA, tokenB)
+}
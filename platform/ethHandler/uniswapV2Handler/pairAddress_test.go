@@ -0,0 +1,40 @@
+package uniswapV2Handler
+
+import (
+	"testing"
+
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Every pair of the registry must be found at its CREATE2 address
+func TestComputePairAddress(t *testing.T) {
+	for key, pair := range pairsMap {
+		t.Run(key, func(t *testing.T) {
+			config, err := GetConfig(pair.PlatformName)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token0, err := ethHandler.GetToken(pair.ChainId, pair.Token0Symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token1, err := ethHandler.GetToken(pair.ChainId, pair.Token1Symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := common.HexToAddress(pair.PairAddress)
+			if got := config.ComputePairAddress(token0.AddressForGeth(), token1.AddressForGeth()); got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+
+			// The address does not depend on the order of the tokens
+			if got := config.ComputePairAddress(token1.AddressForGeth(), token0.AddressForGeth()); got != want {
+				t.Errorf("reversed tokens: got %v, want %v", got, want)
+			}
+		})
+	}
+}
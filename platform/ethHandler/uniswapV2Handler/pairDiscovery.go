@@ -0,0 +1,527 @@
+package uniswapV2Handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV2Factory"
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV2Pair"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/Opulentia-Trading/Arbitrage/util"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	indexBatchSize      = 1000 // pairs enumerated per multicall round, the index is saved after each round
+	eventPollInterval   = 15 * time.Second
+	maxEventBlockRange  = 5000 // providers cap the block range of eth_getLogs
+	unknownPairIndex    = -1
+	pairIndexDirRelPath = "../../../db"
+)
+
+type IndexedPair struct {
+	Index   int64          `json:"index"` // position in allPairs, unknownPairIndex if resolved through getPair
+	Address common.Address `json:"address"`
+	Token0  common.Address `json:"token0"`
+	Token1  common.Address `json:"token1"`
+}
+
+// Persisted index of the pairs created by a factory
+type PairIndex struct {
+	ChainId        ethHandler.ChainId `json:"chainId"`
+	FactoryAddress common.Address     `json:"factoryAddress"`
+	NextIndex      uint64             `json:"nextIndex"` // allPairs are enumerated up to this index
+	LastBlock      uint64             `json:"lastBlock"` // PairCreated events are processed up to this block
+	Pairs          []*IndexedPair     `json:"pairs"`
+}
+
+// Discovers the pairs of a Uniswap V2 factory.
+// Pairs are resolved on demand through getPair, bulk-enumerated from allPairs
+// and kept current by following PairCreated events.
+// The persisted index is loaded on first use rather than when the discovery is created.
+type PairDiscovery struct {
+	handler   *ethHandler.EthHandler
+	factory   *uniswapV2Factory.UniswapV2Factory
+	indexPath string
+
+	loadOnce sync.Once
+	loadErr  error
+
+	mu       sync.RWMutex
+	index    *PairIndex
+	byTokens map[string]*IndexedPair
+	version  uint64 // incremented whenever a pair is added to the index
+}
+
+func NewPairDiscovery(handler *ethHandler.EthHandler, factoryAddress common.Address, indexPath string) (*PairDiscovery, error) {
+	factory, err := uniswapV2Factory.NewUniswapV2Factory(factoryAddress, handler.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &PairDiscovery{
+		handler:   handler,
+		factory:   factory,
+		indexPath: indexPath,
+		index: &PairIndex{
+			ChainId:        handler.Network.ChainId,
+			FactoryAddress: factoryAddress,
+		},
+		byTokens: make(map[string]*IndexedPair),
+	}
+
+	return d, nil
+}
+
+// Loads the persisted index once. LookupPair and Pairs see an empty index if it fails to load,
+// the error is returned by the other methods.
+func (d *PairDiscovery) ensureLoaded() error {
+	d.loadOnce.Do(func() {
+		d.mu.Lock()
+		d.loadErr = d.load()
+		d.mu.Unlock()
+
+		if d.loadErr != nil {
+			fmt.Printf("unable to load pair index: %v\n", d.loadErr)
+		}
+	})
+
+	return d.loadErr
+}
+
+// Index files are stored in the db directory at the root of the repo
+func DefaultPairIndexPath(chainId ethHandler.ChainId, factoryAddress common.Address) (string, error) {
+	dirname, err := util.CurDirname()
+	if err != nil {
+		return "", err
+	}
+
+	filename := fmt.Sprintf("uniswap_v2_pairs_%v_%v.json", chainId, strings.ToLower(factoryAddress.Hex()))
+	return filepath.Join(dirname, pairIndexDirRelPath, filename), nil
+}
+
+func genTokensKey(tokenA common.Address, tokenB common.Address) string {
+	token0, token1 := ethHandler.SortTokens(tokenA, tokenB)
+	return token0.Hex() + "|" + token1.Hex()
+}
+
+// Returns the address of the pair of two tokens, querying the factory if it is not indexed yet
+func (d *PairDiscovery) ResolvePair(tokenA common.Address, tokenB common.Address) (*IndexedPair, error) {
+	if err := d.ensureLoaded(); err != nil {
+		return nil, err
+	}
+
+	if pair := d.LookupPair(tokenA, tokenB); pair != nil {
+		return pair, nil
+	}
+
+	pairAddress, err := d.factory.GetPair(&bind.CallOpts{}, tokenA, tokenB)
+	if err != nil {
+		return nil, err
+	}
+
+	if pairAddress == (common.Address{}) {
+		return nil, fmt.Errorf("no pair for tokens %v and %v", tokenA, tokenB)
+	}
+
+	token0, token1 := ethHandler.SortTokens(tokenA, tokenB)
+	pair := &IndexedPair{
+		Index:   unknownPairIndex,
+		Address: pairAddress,
+		Token0:  token0,
+		Token1:  token1,
+	}
+
+	d.mu.Lock()
+	d.addPair(pair)
+	d.mu.Unlock()
+
+	return pair, nil
+}
+
+// Returns the indexed pair of two tokens or nil
+func (d *PairDiscovery) LookupPair(tokenA common.Address, tokenB common.Address) *IndexedPair {
+	d.ensureLoaded()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.byTokens[genTokensKey(tokenA, tokenB)]
+}
+
+func (d *PairDiscovery) Pairs() []*IndexedPair {
+	d.ensureLoaded()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := make([]*IndexedPair, len(d.index.Pairs))
+	copy(result, d.index.Pairs)
+	return result
+}
+
+// Returns a number which changes whenever pairs are added, so that state derived
+// from the pairs knows when to refresh
+func (d *PairDiscovery) Version() uint64 {
+	d.ensureLoaded()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.version
+}
+
+// Enumerates allPairs from where the last run stopped and persists the index.
+// Mainnet has hundreds of thousands of pairs, so progress is saved periodically
+// and the enumeration can be interrupted through the context.
+func (d *PairDiscovery) IndexAllPairs(ctx context.Context) error {
+	if err := d.ensureLoaded(); err != nil {
+		return err
+	}
+
+	header, err := d.handler.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Pairs created after this block are picked up by following PairCreated events
+	callOpts := &bind.CallOpts{Context: ctx, BlockNumber: header.Number}
+	pairsLength, err := d.factory.AllPairsLength(callOpts)
+	if err != nil {
+		return err
+	}
+
+	multicaller, err := d.handler.NewMulticaller()
+	if err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	nextIndex := d.index.NextIndex
+	d.mu.RUnlock()
+
+	for start := nextIndex; start < pairsLength.Uint64(); start += indexBatchSize {
+		if err := ctx.Err(); err != nil {
+			return d.saveAndReturn(err)
+		}
+
+		end := start + indexBatchSize
+		if end > pairsLength.Uint64() {
+			end = pairsLength.Uint64()
+		}
+
+		pairs, err := d.fetchPairs(multicaller, callOpts, start, end)
+		if err != nil {
+			return d.saveAndReturn(err)
+		}
+
+		d.mu.Lock()
+		for _, pair := range pairs {
+			d.addPair(pair)
+		}
+		d.index.NextIndex = end
+		d.mu.Unlock()
+
+		if err := d.Save(); err != nil {
+			return err
+		}
+	}
+
+	d.mu.Lock()
+	if d.index.LastBlock < header.Number.Uint64() {
+		d.index.LastBlock = header.Number.Uint64()
+	}
+	d.mu.Unlock()
+
+	return d.Save()
+}
+
+// Reads the pairs of the allPairs indexes [start, end) in two multicalls:
+// the pair addresses first, then the tokens of every pair
+func (d *PairDiscovery) fetchPairs(
+	multicaller *ethHandler.Multicaller,
+	callOpts *bind.CallOpts,
+	start uint64,
+	end uint64,
+) ([]*IndexedPair, error) {
+	factoryAbi, err := uniswapV2Factory.UniswapV2FactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	pairAbi, err := uniswapV2Pair.UniswapV2PairMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	factoryAddress := d.index.FactoryAddress
+	addressCalls := make([]*ethHandler.MulticallCall, 0, end-start)
+	for i := start; i < end; i++ {
+		addressCalls = append(addressCalls, ethHandler.NewMulticallCall(factoryAddress, factoryAbi, "allPairs", new(big.Int).SetUint64(i)))
+	}
+
+	addressResults, err := multicaller.Call(callOpts, addressCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]*IndexedPair, len(addressResults))
+	tokenCalls := make([]*ethHandler.MulticallCall, 0, 2*len(addressResults))
+	for i, result := range addressResults {
+		pairs[i] = &IndexedPair{
+			Index:   int64(start) + int64(i),
+			Address: result.Outputs[0].(common.Address),
+		}
+		tokenCalls = append(tokenCalls,
+			ethHandler.NewMulticallCall(pairs[i].Address, pairAbi, "token0"),
+			ethHandler.NewMulticallCall(pairs[i].Address, pairAbi, "token1"))
+	}
+
+	tokenResults, err := multicaller.Call(callOpts, tokenCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, pair := range pairs {
+		pair.Token0 = tokenResults[2*i].Outputs[0].(common.Address)
+		pair.Token1 = tokenResults[2*i+1].Outputs[0].(common.Address)
+	}
+
+	return pairs, nil
+}
+
+// Keeps the index current by following PairCreated events until the context is cancelled.
+// Subscribes to events over WebSockets and falls back to polling the logs over Https.
+func (d *PairDiscovery) FollowPairCreated(ctx context.Context) error {
+	if err := d.ensureLoaded(); err != nil {
+		return err
+	}
+
+	if d.handler.ProviderProtocol == ethHandler.WebSockets {
+		err := d.watchPairCreated(ctx)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		fmt.Printf("PairCreated subscription failed, falling back to polling: %v\n", err)
+	}
+
+	return d.pollPairCreated(ctx)
+}
+
+func (d *PairDiscovery) watchPairCreated(ctx context.Context) error {
+	// Catch up on the events emitted since the index was last updated
+	if err := d.processNewEvents(ctx); err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	startBlock := d.index.LastBlock + 1
+	d.mu.RUnlock()
+
+	sink := make(chan *uniswapV2Factory.UniswapV2FactoryPairCreated)
+	sub, err := d.factory.WatchPairCreated(&bind.WatchOpts{Start: &startBlock, Context: ctx}, sink, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case event := <-sink:
+			if event.Raw.Removed {
+				continue
+			}
+
+			d.mu.Lock()
+			d.addEvent(event)
+			d.mu.Unlock()
+
+			if err := d.Save(); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (d *PairDiscovery) pollPairCreated(ctx context.Context) error {
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.processNewEvents(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Processes the PairCreated events between the last processed block and the latest block
+func (d *PairDiscovery) processNewEvents(ctx context.Context) error {
+	latestBlock, err := d.handler.Client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	lastBlock := d.index.LastBlock
+	d.mu.RUnlock()
+
+	if lastBlock == 0 {
+		// Following events from genesis would be slower than enumerating allPairs
+		return errors.New("pair index is empty, run IndexAllPairs first")
+	}
+
+	fromBlock := lastBlock + 1
+	for fromBlock <= latestBlock {
+		toBlock := fromBlock + maxEventBlockRange - 1
+		if toBlock > latestBlock {
+			toBlock = latestBlock
+		}
+
+		iter, err := d.factory.FilterPairCreated(&bind.FilterOpts{Start: fromBlock, End: &toBlock, Context: ctx}, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		d.mu.Lock()
+		for iter.Next() {
+			d.addEvent(iter.Event)
+		}
+		d.index.LastBlock = toBlock
+		d.mu.Unlock()
+
+		err = iter.Error()
+		iter.Close()
+		if err != nil {
+			return err
+		}
+
+		if err := d.Save(); err != nil {
+			return err
+		}
+
+		fromBlock = toBlock + 1
+	}
+
+	return nil
+}
+
+// Must be called with the lock held
+func (d *PairDiscovery) addEvent(event *uniswapV2Factory.UniswapV2FactoryPairCreated) {
+	// The last event argument is the length of allPairs after the pair was added
+	index := event.Arg3.Int64() - 1
+	d.addPair(&IndexedPair{
+		Index:   index,
+		Address: event.Pair,
+		Token0:  event.Token0,
+		Token1:  event.Token1,
+	})
+
+	if uint64(index) == d.index.NextIndex {
+		d.index.NextIndex++
+	}
+
+	if event.Raw.BlockNumber > d.index.LastBlock {
+		d.index.LastBlock = event.Raw.BlockNumber
+	}
+}
+
+// Must be called with the lock held
+func (d *PairDiscovery) addPair(pair *IndexedPair) {
+	key := genTokensKey(pair.Token0, pair.Token1)
+	if existing, found := d.byTokens[key]; found {
+		if existing.Index == unknownPairIndex {
+			existing.Index = pair.Index
+		}
+		return
+	}
+
+	d.index.Pairs = append(d.index.Pairs, pair)
+	d.byTokens[key] = pair
+	d.version++
+}
+
+// Must be called with the lock held
+func (d *PairDiscovery) load() error {
+	data, err := os.ReadFile(d.indexPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var index PairIndex
+	err = json.Unmarshal(data, &index)
+	if err != nil {
+		return err
+	}
+
+	if index.ChainId != d.index.ChainId || index.FactoryAddress != d.index.FactoryAddress {
+		return fmt.Errorf("pair index %v belongs to another factory", d.indexPath)
+	}
+
+	pairs := index.Pairs
+	index.Pairs = nil
+	d.index = &index
+	for _, pair := range pairs {
+		d.addPair(pair)
+	}
+
+	return nil
+}
+
+// Writes the index to a temporary file first so that an interrupted save keeps the previous index
+func (d *PairDiscovery) Save() error {
+	// Saving before the index is loaded would overwrite it
+	if err := d.ensureLoaded(); err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	data, err := json.Marshal(d.index)
+	d.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(d.indexPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := d.indexPath + ".tmp"
+	err = os.WriteFile(tmpPath, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, d.indexPath)
+}
+
+func (d *PairDiscovery) saveAndReturn(err error) error {
+	if saveErr := d.Save(); saveErr != nil {
+		fmt.Printf("unable to save pair index: %v\n", saveErr)
+	}
+
+	return err
+}
@@ -0,0 +1,221 @@
+package uniswapV2Handler
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const defaultMaxHops = 3
+
+// Graph of the known pairs of a platform.
+// Intermediate tokens of a path are restricted to the tokens registry, which keeps
+// the search small even when the pair discovery index holds every pair of the factory.
+type pairGraph struct {
+	edges         map[string]bool // genTokensKey of the tokens of every known pair
+	intermediates []common.Address
+	pairsVersion  uint64 // version of the pair discovery index the graph was built from
+}
+
+// Returns the pair graph, rebuilt when pair discovery added pairs or tokens were
+// registered since it was built
+func (h *UniswapV2Handler) getPairGraph() (*pairGraph, error) {
+	var pairsVersion uint64
+	if h.PairDiscovery != nil {
+		pairsVersion = h.PairDiscovery.Version()
+	}
+	tokenCount := len(ethHandler.GetChainTokens(h.Network.ChainId))
+
+	h.graphMu.Lock()
+	defer h.graphMu.Unlock()
+
+	if h.graph != nil && h.graph.pairsVersion == pairsVersion && len(h.graph.intermediates) == tokenCount {
+		return h.graph, nil
+	}
+
+	graph, err := h.buildPairGraph(pairsVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	h.graph = graph
+	return graph, nil
+}
+
+func (h *UniswapV2Handler) buildPairGraph(pairsVersion uint64) (*pairGraph, error) {
+	graph := &pairGraph{edges: make(map[string]bool), pairsVersion: pairsVersion}
+
+	for _, pair := range pairsMap {
+		if pair.PlatformName != h.Config.PlatformName || pair.ChainId != h.Network.ChainId {
+			continue
+		}
+
+		token0, err := ethHandler.GetToken(pair.ChainId, pair.Token0Symbol)
+		if err != nil {
+			return nil, err
+		}
+
+		token1, err := ethHandler.GetToken(pair.ChainId, pair.Token1Symbol)
+		if err != nil {
+			return nil, err
+		}
+
+		graph.edges[genTokensKey(token0.AddressForGeth(), token1.AddressForGeth())] = true
+	}
+
+	if h.PairDiscovery != nil {
+		for _, pair := range h.PairDiscovery.Pairs() {
+			graph.edges[genTokensKey(pair.Token0, pair.Token1)] = true
+		}
+	}
+
+	for _, token := range ethHandler.GetChainTokens(h.Network.ChainId) {
+		graph.intermediates = append(graph.intermediates, token.AddressForGeth())
+	}
+
+	return graph, nil
+}
+
+func (g *pairGraph) hasPair(tokenA common.Address, tokenB common.Address) bool {
+	return g.edges[genTokensKey(tokenA, tokenB)]
+}
+
+// Returns every path of known pairs from tokenIn to tokenOut with at most maxHops pairs
+func (g *pairGraph) findPaths(tokenIn common.Address, tokenOut common.Address, maxHops int) [][]common.Address {
+	var result [][]common.Address
+	visited := map[common.Address]bool{tokenIn: true}
+
+	var search func(path []common.Address)
+	search = func(path []common.Address) {
+		last := path[len(path)-1]
+		if g.hasPair(last, tokenOut) {
+			result = append(result, append(append([]common.Address{}, path...), tokenOut))
+		}
+
+		if len(path) >= maxHops {
+			return
+		}
+
+		for _, token := range g.intermediates {
+			if visited[token] || token == tokenOut || !g.hasPair(last, token) {
+				continue
+			}
+
+			visited[token] = true
+			search(append(path, token))
+			visited[token] = false
+		}
+	}
+
+	search([]common.Address{tokenIn})
+	return result
+}
+
+// Searches the known pairs for the path with the best output of an exact input swap
+// or the lowest input of an exact output swap. Paths are evaluated with exact V2 math
+// for the requested amount, so the best path depends on the size of the order.
+func (h *UniswapV2Handler) FindBestPath(
+	tokenIn common.Address,
+	tokenOut common.Address,
+	amount *big.Int,
+	swapKind models.SwapKind,
+) (*Quote, error) {
+	maxHops := h.MaxHops
+	if maxHops <= 0 {
+		maxHops = defaultMaxHops
+	}
+
+	graph, err := h.getPairGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := graph.findPaths(tokenIn, tokenOut, maxHops)
+	if len(paths) == 0 {
+		// Fall back to the direct pair which may exist without being known
+		if h.PairDiscovery != nil {
+			if _, err := h.PairDiscovery.ResolvePair(tokenIn, tokenOut); err != nil {
+				return nil, fmt.Errorf("no path from %v to %v: %w", tokenIn, tokenOut, err)
+			}
+		}
+		paths = [][]common.Address{{tokenIn, tokenOut}}
+	}
+
+	// Every path is quoted at the same block, the reserves of all their pairs are read at once
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []hopKey
+	for _, path := range paths {
+		for i := 0; i < len(path)-1; i++ {
+			keys = append(keys, hopKey{path[i], path[i+1]})
+		}
+	}
+
+	reserves, err := h.fetchHopsReserves(snapshot, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var bestQuote *Quote
+	var lastErr error
+	for _, path := range paths {
+		quote, err := h.quotePath(path, amount, swapKind, reserves)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if bestQuote == nil || isBetterQuote(quote, bestQuote, swapKind) {
+			bestQuote = quote
+		}
+	}
+
+	if bestQuote == nil {
+		return nil, fmt.Errorf("no path from %v to %v: %w", tokenIn, tokenOut, lastErr)
+	}
+
+	return bestQuote, nil
+}
+
+func (h *UniswapV2Handler) quotePath(
+	path []common.Address,
+	amount *big.Int,
+	swapKind models.SwapKind,
+	reserves *hopsReserves,
+) (*Quote, error) {
+	hops := make([]*HopReserves, len(path)-1)
+	for i := 0; i < len(path)-1; i++ {
+		hop, err := reserves.get(hopKey{path[i], path[i+1]})
+		if err != nil {
+			return nil, err
+		}
+		hops[i] = hop
+	}
+
+	var amounts []*big.Int
+	var err error
+	if swapKind == models.ExactOutput {
+		amounts, err = GetAmountsIn(amount, hops, h.Config.FeeBps)
+	} else {
+		amounts, err = GetAmountsOut(amount, hops, h.Config.FeeBps)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return NewQuote(amounts, hops), nil
+}
+
+func isBetterQuote(quote *Quote, bestQuote *Quote, swapKind models.SwapKind) bool {
+	if swapKind == models.ExactOutput {
+		return quote.AmountIn.Cmp(bestQuote.AmountIn) < 0
+	}
+
+	return quote.AmountOut.Cmp(bestQuote.AmountOut) > 0
+}
@@ -0,0 +1,57 @@
+package uniswapV2Handler
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The graph is built once and rebuilt when pair discovery adds pairs
+func TestPairGraphRefresh(t *testing.T) {
+	config, err := GetConfig(PlatformName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	network, err := ethHandler.GetEvmNetwork(config.NetworkName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ethHandlerInst := &ethHandler.EthHandler{Network: network}
+	indexPath := filepath.Join(t.TempDir(), "pairs.json")
+	discovery, err := NewPairDiscovery(ethHandlerInst, config.FactoryAddressForGeth(), indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &UniswapV2Handler{EthHandler: ethHandlerInst, Config: config, PairDiscovery: discovery}
+	graph, err := h.getPairGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cached, err := h.getPairGraph(); err != nil || cached != graph {
+		t.Fatalf("graph rebuilt without new pairs (%v)", err)
+	}
+
+	tokenA := common.HexToAddress("0x000000000000000000000000000000000000aaa1")
+	tokenB := common.HexToAddress("0x000000000000000000000000000000000000aaa2")
+	if graph.hasPair(tokenA, tokenB) {
+		t.Fatal("unexpected pair")
+	}
+
+	discovery.mu.Lock()
+	discovery.addPair(&IndexedPair{Index: unknownPairIndex, Token0: tokenA, Token1: tokenB})
+	discovery.mu.Unlock()
+
+	refreshed, err := h.getPairGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed == graph || !refreshed.hasPair(tokenA, tokenB) {
+		t.Error("graph not refreshed with the discovered pair")
+	}
+}
@@ -0,0 +1,450 @@
+package uniswapV2Handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV2Pair"
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Reserves of a pair as of a block
+type CachedReserves struct {
+	Pair        *PairWrapper
+	Reserve0    *big.Int
+	Reserve1    *big.Int
+	BlockNumber uint64 // the reserves are current as of this block
+}
+
+// In-memory reserves of a set of pairs, kept current from their Sync events.
+// Every pair emits Sync with its new reserves whenever they change, so the last
+// Sync event of a pair is its state.
+// The reserves replaced in recent blocks are journaled so that a reorg rolls the
+// cache back to the fork point before the events of the new branch are applied.
+type ReserveCache struct {
+	handler   *UniswapV2Handler
+	headers   ethHandler.HeaderReader
+	syncTopic common.Hash
+	instances map[common.Address]*uniswapV2Pair.UniswapV2Pair
+
+	mu        sync.RWMutex
+	reserves  map[common.Address]*CachedReserves
+	lastBlock uint64 // last block whose events were processed
+	hashes    *ethHandler.BlockHashWindow
+	journal   map[uint64]map[common.Address]CachedReserves // reserves before the first Sync of each pair in a block
+}
+
+func NewReserveCache(handler *UniswapV2Handler, pairs []*PairWrapper) (*ReserveCache, error) {
+	pairAbi, err := uniswapV2Pair.UniswapV2PairMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &ReserveCache{
+		handler:   handler,
+		headers:   handler.Client,
+		syncTopic: pairAbi.Events["Sync"].ID,
+		instances: make(map[common.Address]*uniswapV2Pair.UniswapV2Pair),
+		reserves:  make(map[common.Address]*CachedReserves),
+		hashes:    ethHandler.NewBlockHashWindow(),
+		journal:   make(map[uint64]map[common.Address]CachedReserves),
+	}
+
+	for _, pair := range pairs {
+		pairAddress := common.HexToAddress(pair.PairAddress)
+		instance, err := uniswapV2Pair.NewUniswapV2Pair(pairAddress, handler.Client)
+		if err != nil {
+			return nil, err
+		}
+
+		cache.instances[pairAddress] = instance
+		cache.reserves[pairAddress] = &CachedReserves{Pair: pair}
+	}
+
+	return cache, nil
+}
+
+// Returns a cache of the registry pairs of the handler's platform and network
+func (h *UniswapV2Handler) NewRegistryReserveCache() (*ReserveCache, error) {
+	return NewReserveCache(h, h.registryPairs())
+}
+
+// Loads the reserves of every pair at the latest block, then keeps them current
+// until the context is cancelled. The logs of every new head are read over WebSockets,
+// the logs are polled over Https or if the subscription fails.
+func (c *ReserveCache) Run(ctx context.Context) error {
+	if err := c.load(ctx); err != nil {
+		return err
+	}
+
+	if c.handler.ProviderProtocol == ethHandler.WebSockets {
+		err := c.watchSync(ctx)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		fmt.Printf("block subscription failed, falling back to polling: %v\n", err)
+	}
+
+	return c.pollSync(ctx)
+}
+
+func (c *ReserveCache) load(ctx context.Context) error {
+	header, err := c.handler.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	blockNumber := header.Number.Uint64()
+
+	pairs := make([]*PairWrapper, 0, len(c.reserves))
+	for _, cached := range c.reserves {
+		pairs = append(pairs, cached.Pair)
+	}
+
+	snapshot := &ethHandler.ChainSnapshot{BlockNumber: header.Number, Context: ctx}
+	reserves, err := c.handler.fetchReservesBatch(pairs, snapshot)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	for i, pair := range pairs {
+		if reserves[i] == nil {
+			c.mu.Unlock()
+			return fmt.Errorf("unable to fetch reserves of pair %v", pair)
+		}
+		*c.reserves[common.HexToAddress(pair.PairAddress)] = *reserves[i]
+	}
+	c.lastBlock = blockNumber
+	c.hashes = ethHandler.NewBlockHashWindow()
+	c.hashes.Add(blockNumber, header.Hash())
+	c.journal = make(map[uint64]map[common.Address]CachedReserves)
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Follows the new heads of the chain. The Sync events of every pair in a block are read with
+// a single log filter, then every pair is current as of the block, whether it emitted events or not.
+func (c *ReserveCache) watchSync(ctx context.Context) error {
+	return c.handler.ForEachBlock(ctx, func(event *ethHandler.BlockEvent) error {
+		return c.processBlock(ctx, event)
+	})
+}
+
+func (c *ReserveCache) processBlock(ctx context.Context, event *ethHandler.BlockEvent) error {
+	blockNumber := event.Number()
+	blockHash := event.Header.Hash()
+
+	c.mu.Lock()
+	if event.Reorg != nil {
+		c.rollback(event.Reorg.ForkPoint.Number.Uint64())
+	}
+	fromBlock := c.lastBlock + 1
+	c.mu.Unlock()
+
+	// Already loaded
+	if blockNumber < fromBlock {
+		return nil
+	}
+
+	// Blocks mined between the load and the first head
+	if fromBlock < blockNumber {
+		query := c.syncQuery()
+		query.FromBlock = new(big.Int).SetUint64(fromBlock)
+		query.ToBlock = new(big.Int).SetUint64(blockNumber - 1)
+		if err := c.applySyncLogs(ctx, query, blockNumber-1); err != nil {
+			return err
+		}
+	}
+
+	query := c.syncQuery()
+	query.BlockHash = &blockHash
+	if err := c.applySyncLogs(ctx, query, blockNumber); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.hashes.Add(blockNumber, blockHash)
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *ReserveCache) pollSync(ctx context.Context) error {
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.processNewEvents(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Applies the Sync events between the last processed block and the latest block.
+// Every pair is current as of the latest block afterwards, whether it emitted events or not.
+// The cache is rolled back first if blocks it processed were reorged, or reloaded if
+// the reorg is deeper than its window of recent blocks.
+func (c *ReserveCache) processNewEvents(ctx context.Context) error {
+	canonical, err := c.rollbackToCanonical(ctx)
+	if err != nil {
+		return err
+	}
+	if !canonical {
+		return c.load(ctx)
+	}
+
+	c.mu.RLock()
+	fromBlock := c.lastBlock + 1
+	c.mu.RUnlock()
+
+	latestHeader, err := c.handler.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	latestBlock := latestHeader.Number.Uint64()
+
+	for fromBlock <= latestBlock {
+		toBlock := fromBlock + maxEventBlockRange - 1
+		if toBlock > latestBlock {
+			toBlock = latestBlock
+		}
+
+		query := c.syncQuery()
+		query.FromBlock = new(big.Int).SetUint64(fromBlock)
+		query.ToBlock = new(big.Int).SetUint64(toBlock)
+		if err := c.applySyncLogs(ctx, query, toBlock); err != nil {
+			return err
+		}
+
+		if toBlock == latestBlock {
+			c.mu.Lock()
+			c.hashes.Add(latestBlock, latestHeader.Hash())
+			c.mu.Unlock()
+		}
+
+		fromBlock = toBlock + 1
+	}
+
+	return nil
+}
+
+// Rolls the cache back to the newest processed block still on the canonical chain.
+// Returns false if the reorg is deeper than the window of recent blocks, the cache has to be reloaded then.
+func (c *ReserveCache) rollbackToCanonical(ctx context.Context) (bool, error) {
+	c.mu.RLock()
+	forkPoint, err := c.hashes.FindForkPoint(ctx, c.headers)
+	c.mu.RUnlock()
+	if errors.Is(err, ethHandler.ErrReorgTooDeep) {
+		fmt.Printf("reloading reserves: %v\n", err)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	c.rollback(forkPoint)
+	c.mu.Unlock()
+
+	return true, nil
+}
+
+// Returns a filter of the Sync events of every cached pair
+func (c *ReserveCache) syncQuery() ethereum.FilterQuery {
+	query := ethereum.FilterQuery{
+		Addresses: make([]common.Address, 0, len(c.instances)),
+		Topics:    [][]common.Hash{{c.syncTopic}},
+	}
+	for pairAddress := range c.instances {
+		query.Addresses = append(query.Addresses, pairAddress)
+	}
+
+	return query
+}
+
+// Applies the Sync events matching a filter in the order they were emitted, then marks
+// every pair current as of a block. Readers never see a partially applied block range.
+func (c *ReserveCache) applySyncLogs(ctx context.Context, query ethereum.FilterQuery, blockNumber uint64) error {
+	// A filter without addresses would match the Sync events of every pair of the chain
+	var logs []types.Log
+	if len(query.Addresses) > 0 {
+		var err error
+		logs, err = c.handler.Client.FilterLogs(ctx, query)
+		if err != nil {
+			return fmt.Errorf("unable to filter Sync events: %w", err)
+		}
+	}
+
+	events := make([]*uniswapV2Pair.UniswapV2PairSync, 0, len(logs))
+	for _, log := range logs {
+		instance, found := c.instances[log.Address]
+		if !found {
+			return fmt.Errorf("log of unknown pair %v", log.Address)
+		}
+
+		event, err := instance.ParseSync(log)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+
+	c.applySyncEvents(events, blockNumber)
+	return nil
+}
+
+// Applies Sync events in order, then marks every pair current as of a block
+func (c *ReserveCache) applySyncEvents(events []*uniswapV2Pair.UniswapV2PairSync, blockNumber uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, event := range events {
+		c.applySync(event)
+	}
+	for _, cached := range c.reserves {
+		cached.BlockNumber = blockNumber
+	}
+	c.lastBlock = blockNumber
+}
+
+// Must be called with the lock held
+func (c *ReserveCache) applySync(event *uniswapV2Pair.UniswapV2PairSync) {
+	cached, found := c.reserves[event.Raw.Address]
+	if !found {
+		return
+	}
+
+	blockJournal, found := c.journal[event.Raw.BlockNumber]
+	if !found {
+		blockJournal = make(map[common.Address]CachedReserves)
+		c.journal[event.Raw.BlockNumber] = blockJournal
+	}
+	if _, found := blockJournal[event.Raw.Address]; !found {
+		blockJournal[event.Raw.Address] = *cached
+	}
+	c.hashes.Add(event.Raw.BlockNumber, event.Raw.BlockHash)
+
+	cached.Reserve0 = event.Reserve0
+	cached.Reserve1 = event.Reserve1
+	if event.Raw.BlockNumber > c.lastBlock {
+		c.lastBlock = event.Raw.BlockNumber
+	}
+
+	for blockNumber := range c.journal {
+		if blockNumber+ethHandler.MaxReorgDepth <= c.lastBlock {
+			delete(c.journal, blockNumber)
+		}
+	}
+}
+
+// Restores the reserves as of a block, undoing the Sync events of the blocks after it.
+// Must be called with the lock held.
+func (c *ReserveCache) rollback(blockNumber uint64) {
+	if blockNumber >= c.lastBlock {
+		return
+	}
+
+	var blocks []uint64
+	for journaled := range c.journal {
+		if journaled > blockNumber {
+			blocks = append(blocks, journaled)
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] > blocks[j] })
+
+	for _, journaled := range blocks {
+		for pairAddress, previous := range c.journal[journaled] {
+			*c.reserves[pairAddress] = previous
+		}
+		delete(c.journal, journaled)
+	}
+
+	for _, cached := range c.reserves {
+		if cached.BlockNumber > blockNumber {
+			cached.BlockNumber = blockNumber
+		}
+	}
+
+	fmt.Printf("reorg: reserves rolled back from block %v to block %v\n", c.lastBlock, blockNumber)
+	c.lastBlock = blockNumber
+	c.hashes.Rollback(blockNumber)
+}
+
+// Returns a copy of the cached reserves of a pair, or nil if the pair is not cached or not loaded yet
+func (c *ReserveCache) Reserves(pairAddress common.Address) *CachedReserves {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, found := c.reserves[pairAddress]
+	if !found || cached.Reserve0 == nil {
+		return nil
+	}
+
+	result := *cached
+	return &result
+}
+
+// Returns the last block whose events were applied
+func (c *ReserveCache) LastBlock() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.lastBlock
+}
+
+// Returns the price of a pair from its cached reserves.
+// Only reserves of the block of the snapshot are used, or of any block if the snapshot is nil.
+func (h *UniswapV2Handler) cachedPairPrice(pair *PairWrapper, snapshot *ethHandler.ChainSnapshot) (models.TickerInfo, bool) {
+	if h.StateCache == nil {
+		return models.TickerInfo{}, false
+	}
+
+	cached := h.StateCache.Reserves(common.HexToAddress(pair.PairAddress))
+	if cached == nil || (snapshot != nil && cached.BlockNumber != snapshot.Number()) {
+		return models.TickerInfo{}, false
+	}
+
+	ticker, err := h.reservesTickerInfo(pair, cached.Reserve0, cached.Reserve1, cached.BlockNumber)
+	if err != nil {
+		return models.TickerInfo{}, false
+	}
+
+	return ticker, true
+}
+
+// Splits pairs into the prices served from the cache and the pairs missing from it
+func (h *UniswapV2Handler) cachedPairPrices(
+	pairs []*PairWrapper,
+	snapshot *ethHandler.ChainSnapshot,
+) ([]models.TickerInfo, []*PairWrapper) {
+	var result []models.TickerInfo
+	var uncachedPairs []*PairWrapper
+	for _, pair := range pairs {
+		if ticker, found := h.cachedPairPrice(pair, snapshot); found {
+			result = append(result, ticker)
+			continue
+		}
+		uncachedPairs = append(uncachedPairs, pair)
+	}
+
+	return result, uncachedPairs
+}
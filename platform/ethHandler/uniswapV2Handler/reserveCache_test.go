@@ -0,0 +1,184 @@
+package uniswapV2Handler
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV2Pair"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Headers of the canonical chain by number
+type fakeHeaders map[uint64]*types.Header
+
+func (f fakeHeaders) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if header, found := f[number.Uint64()]; found {
+		return header, nil
+	}
+
+	return nil, ethereum.NotFound
+}
+
+func (f fakeHeaders) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	for _, header := range f {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+
+	return nil, ethereum.NotFound
+}
+
+// Returns the header of a block of a branch, branches are told apart by their extra data
+func testHeader(number uint64, branch string) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte(branch)}
+}
+
+// Makes blocks from-to of a branch canonical
+func (f fakeHeaders) extend(from uint64, to uint64, branch string) {
+	for number := from; number <= to; number++ {
+		f[number] = testHeader(number, branch)
+	}
+}
+
+var (
+	testPairA = common.HexToAddress("0x000000000000000000000000000000000000a001")
+	testPairB = common.HexToAddress("0x000000000000000000000000000000000000b001")
+)
+
+// Returns a cache of pairs A and B loaded at block 100 of branch a
+func newTestReserveCache(t *testing.T, headers fakeHeaders) *ReserveCache {
+	t.Helper()
+
+	pairs := []*PairWrapper{{PairAddress: testPairA.Hex()}, {PairAddress: testPairB.Hex()}}
+	cache, err := NewReserveCache(&UniswapV2Handler{EthHandler: &ethHandler.EthHandler{}}, pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.headers = headers
+
+	*cache.reserves[testPairA] = CachedReserves{Pair: pairs[0], Reserve0: big.NewInt(1000), Reserve1: big.NewInt(2000), BlockNumber: 100}
+	*cache.reserves[testPairB] = CachedReserves{Pair: pairs[1], Reserve0: big.NewInt(10), Reserve1: big.NewInt(20), BlockNumber: 100}
+	cache.lastBlock = 100
+	cache.hashes.Add(100, testHeader(100, "a").Hash())
+
+	return cache
+}
+
+func testSync(pair common.Address, blockNumber uint64, reserve0 int64, reserve1 int64) *uniswapV2Pair.UniswapV2PairSync {
+	return &uniswapV2Pair.UniswapV2PairSync{
+		Reserve0: big.NewInt(reserve0),
+		Reserve1: big.NewInt(reserve1),
+		Raw: types.Log{
+			Address:     pair,
+			BlockNumber: blockNumber,
+			BlockHash:   testHeader(blockNumber, "a").Hash(),
+		},
+	}
+}
+
+// Reserves of pairs A and B
+type testReserves [2][2]int64
+
+func cachedTestReserves(cache *ReserveCache) testReserves {
+	var reserves testReserves
+	for i, pair := range []common.Address{testPairA, testPairB} {
+		cached := cache.Reserves(pair)
+		reserves[i] = [2]int64{cached.Reserve0.Int64(), cached.Reserve1.Int64()}
+	}
+
+	return reserves
+}
+
+func TestReserveCacheRollback(t *testing.T) {
+	// Sync events of blocks 101 to 103 of branch a, and the reserves after each block
+	blocks := map[uint64][]*uniswapV2Pair.UniswapV2PairSync{
+		101: {testSync(testPairA, 101, 1100, 1900)},
+		102: {testSync(testPairA, 102, 1200, 1800), testSync(testPairA, 102, 1300, 1700), testSync(testPairB, 102, 11, 19)},
+		103: {testSync(testPairB, 103, 12, 18)},
+	}
+	states := map[uint64]testReserves{
+		100: {{1000, 2000}, {10, 20}},
+		101: {{1100, 1900}, {10, 20}},
+		102: {{1300, 1700}, {11, 19}},
+		103: {{1300, 1700}, {12, 18}},
+	}
+
+	tests := []struct {
+		name       string
+		forkAt     uint64 // branch b replaces the blocks after it, 0 for no reorg
+		wantReload bool
+		wantBlock  uint64
+	}{
+		{name: "no reorg", wantBlock: 103},
+		{name: "newest block replaced", forkAt: 102, wantBlock: 102},
+		{name: "block with several events of a pair replaced", forkAt: 101, wantBlock: 101},
+		{name: "every block with events replaced", forkAt: 100, wantBlock: 100},
+		{name: "every block of the window replaced", forkAt: 99, wantReload: true, wantBlock: 103},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := fakeHeaders{}
+			headers.extend(100, 103, "a")
+			cache := newTestReserveCache(t, headers)
+			for number := uint64(101); number <= 103; number++ {
+				cache.applySyncEvents(blocks[number], number)
+			}
+			if test.forkAt > 0 {
+				headers.extend(test.forkAt+1, 104, "b")
+			}
+
+			canonical, err := cache.rollbackToCanonical(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if canonical == test.wantReload {
+				t.Fatalf("got reload %v, want %v", !canonical, test.wantReload)
+			}
+
+			if got := cachedTestReserves(cache); got != states[test.wantBlock] {
+				t.Errorf("got reserves %v, want %v", got, states[test.wantBlock])
+			}
+			if cache.LastBlock() != test.wantBlock {
+				t.Errorf("got last block %v, want %v", cache.LastBlock(), test.wantBlock)
+			}
+			for _, pair := range []common.Address{testPairA, testPairB} {
+				if blockNumber := cache.Reserves(pair).BlockNumber; blockNumber != test.wantBlock {
+					t.Errorf("pair %v: got block %v, want %v", pair, blockNumber, test.wantBlock)
+				}
+			}
+		})
+	}
+}
+
+// A reorg below the journaled blocks reloads the cache instead of rolling back
+func TestReserveCacheReorgTooDeep(t *testing.T) {
+	headers := fakeHeaders{}
+	newest := uint64(100 + ethHandler.MaxReorgDepth + 5)
+	headers.extend(100, newest, "a")
+	cache := newTestReserveCache(t, headers)
+	for number := uint64(101); number <= newest; number++ {
+		cache.applySyncEvents([]*uniswapV2Pair.UniswapV2PairSync{testSync(testPairA, number, int64(number), 1)}, number)
+	}
+
+	if len(cache.journal) > ethHandler.MaxReorgDepth {
+		t.Errorf("journal of %v blocks, want at most %v", len(cache.journal), ethHandler.MaxReorgDepth)
+	}
+
+	headers.extend(101, newest+1, "b")
+	canonical, err := cache.rollbackToCanonical(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canonical {
+		t.Fatal("rolled back, want reload")
+	}
+	if cache.LastBlock() != newest {
+		t.Errorf("got last block %v, want %v", cache.LastBlock(), newest)
+	}
+}
@@ -0,0 +1,251 @@
+package uniswapV2Handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/erc20"
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV2Pair"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const transferFeeLookbackBlocks = 2000
+
+// The router has no exact output variants supporting fee-on-transfer tokens
+var ErrExactOutputTransferFee = errors.New("exact output swaps of fee-on-transfer tokens are not supported")
+
+// Transfer fees measured for tokens missing from the tokens registry or discovered from the chain.
+// Each token is measured once, concurrent requests for a token wait for the same measurement.
+type transferFeeCache struct {
+	mu      sync.Mutex
+	fees    map[common.Address]uint
+	pending map[common.Address]*feeMeasurement
+}
+
+// A measurement in progress
+type feeMeasurement struct {
+	done   chan struct{}
+	feeBps uint
+	err    error
+}
+
+func newTransferFeeCache() *transferFeeCache {
+	return &transferFeeCache{
+		fees:    make(map[common.Address]uint),
+		pending: make(map[common.Address]*feeMeasurement),
+	}
+}
+
+// Returns the cached fee of a token, or measures it.
+// The lock is not held during the measurement, so tokens do not wait for each other.
+func (c *transferFeeCache) get(token common.Address, measure func() (uint, error)) (uint, error) {
+	c.mu.Lock()
+	if feeBps, found := c.fees[token]; found {
+		c.mu.Unlock()
+		return feeBps, nil
+	}
+	if measurement, found := c.pending[token]; found {
+		c.mu.Unlock()
+		<-measurement.done
+		return measurement.feeBps, measurement.err
+	}
+
+	measurement := &feeMeasurement{done: make(chan struct{})}
+	c.pending[token] = measurement
+	c.mu.Unlock()
+
+	measurement.feeBps, measurement.err = measure()
+
+	c.mu.Lock()
+	if measurement.err == nil {
+		c.fees[token] = measurement.feeBps
+	}
+	delete(c.pending, token)
+	c.mu.Unlock()
+	close(measurement.done)
+
+	return measurement.feeBps, measurement.err
+}
+
+// Returns the fee in basis points charged by a token on transfers.
+// The tokens shipped with the registry are flagged through Token.TransferFeeBps, discovered
+// and unknown tokens are measured from the recent swaps of the pair if DetectTransferFees is set.
+// Discovered tokens are usually measured in the background when they are discovered.
+func (h *UniswapV2Handler) transferFeeBps(token common.Address, pairAddress common.Address) (uint, error) {
+	registryToken, err := ethHandler.GetTokenByAddress(h.Network.ChainId, token.Hex())
+	if err == nil && !registryToken.Discovered {
+		return registryToken.TransferFeeBps, nil
+	}
+
+	if !h.DetectTransferFees {
+		return 0, nil
+	}
+
+	return h.transferFees.get(token, func() (uint, error) {
+		return h.MeasureTransferFeeBps(token, pairAddress, transferFeeLookbackBlocks)
+	})
+}
+
+// Measures the transfer fee of a token added by DiscoverToken in the background,
+// from its pair with the wrapped native currency
+func (h *UniswapV2Handler) prefetchTransferFee(token *ethHandler.Token) {
+	if !h.DetectTransferFees {
+		return
+	}
+
+	wethToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, h.Network.NativeCurrency.Symbol)
+	if err != nil || wethToken.AddressForGeth() == token.AddressForGeth() {
+		return
+	}
+
+	tokenAddress := token.AddressForGeth()
+	pairAddress := h.Config.ComputePairAddress(tokenAddress, wethToken.AddressForGeth())
+	go func() {
+		if _, err := h.transferFeeBps(tokenAddress, pairAddress); err != nil {
+			fmt.Printf("unable to measure the transfer fee of %v: %v\n", token.Symbol, err)
+		}
+	}()
+}
+
+// Returns true if a token of the path charges a fee on transfers
+func (h *UniswapV2Handler) pathHasTransferFee(path []common.Address) (bool, error) {
+	for i := 0; i < len(path)-1; i++ {
+		pairAddress := h.Config.ComputePairAddress(path[i], path[i+1])
+		for _, token := range path[i : i+2] {
+			feeBps, err := h.transferFeeBps(token, pairAddress)
+			if err != nil {
+				return false, err
+			}
+
+			if feeBps > 0 {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// Measures the transfer fee of a token from the recent swaps of one of its pairs.
+// The pair sends the full output amount of a swap, so the recipient of a taxed token
+// receives less than the amount reported by the Swap event.
+// The fee is inferred from past transfers rather than simulated, so it reads as 0 bps for
+// a token without swaps in the lookback window or which only taxes transfers to the pair (sells).
+// Note: tokens which burn the fee without reducing the Transfer value cannot be detected.
+func (h *UniswapV2Handler) MeasureTransferFeeBps(token common.Address, pairAddress common.Address, lookbackBlocks uint64) (uint, error) {
+	ctx := context.Background()
+	latestBlock, err := h.Client.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	startBlock := uint64(0)
+	if latestBlock > lookbackBlocks {
+		startBlock = latestBlock - lookbackBlocks
+	}
+	filterOpts := &bind.FilterOpts{Start: startBlock, End: &latestBlock, Context: ctx}
+
+	pairInstance, err := uniswapV2Pair.NewUniswapV2Pair(pairAddress, h.Client)
+	if err != nil {
+		return 0, err
+	}
+
+	tokenInstance, err := erc20.NewErc20(token, h.Client)
+	if err != nil {
+		return 0, err
+	}
+
+	// Amount of the token sent by the pair to each recipient, by tx
+	transferIter, err := tokenInstance.FilterTransfer(filterOpts, []common.Address{pairAddress}, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer transferIter.Close()
+
+	received := make(map[common.Hash]map[common.Address]*big.Int)
+	for transferIter.Next() {
+		event := transferIter.Event
+		if received[event.Raw.TxHash] == nil {
+			received[event.Raw.TxHash] = make(map[common.Address]*big.Int)
+		}
+
+		total, found := received[event.Raw.TxHash][event.To]
+		if !found {
+			total = new(big.Int)
+			received[event.Raw.TxHash][event.To] = total
+		}
+		total.Add(total, event.Value)
+	}
+	if err := transferIter.Error(); err != nil {
+		return 0, err
+	}
+
+	swapIter, err := pairInstance.FilterSwap(filterOpts, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer swapIter.Close()
+
+	pairToken0, err := pairInstance.Token0(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	isToken0 := pairToken0 == token
+
+	var maxFeeBps uint
+	for swapIter.Next() {
+		event := swapIter.Event
+		amountOut := event.Amount1Out
+		if isToken0 {
+			amountOut = event.Amount0Out
+		}
+		if amountOut.Sign() == 0 {
+			continue
+		}
+
+		amountReceived, found := received[event.Raw.TxHash][event.To]
+		if !found || amountReceived.Cmp(amountOut) >= 0 {
+			continue
+		}
+
+		// (amountOut - amountReceived) * 10000 / amountOut
+		fee := new(big.Int).Sub(amountOut, amountReceived)
+		fee.Mul(fee, bpsDenominator)
+		fee.Quo(fee, amountOut)
+		if feeBps := uint(fee.Uint64()); feeBps > maxFeeBps {
+			maxFeeBps = feeBps
+		}
+	}
+	if err := swapIter.Error(); err != nil {
+		return 0, err
+	}
+
+	return maxFeeBps, nil
+}
+
+// Returns the amount left after a transfer fee, rounded down like the token contracts
+func applyTransferFee(amount *big.Int, transferFeeBps uint) *big.Int {
+	if transferFeeBps == 0 {
+		return new(big.Int).Set(amount)
+	}
+
+	result := new(big.Int).Mul(amount, feeComplement(transferFeeBps))
+	return result.Quo(result, bpsDenominator)
+}
+
+// Returns the amount to transfer so that at least amount is received after a transfer fee
+func reverseTransferFee(amount *big.Int, transferFeeBps uint) *big.Int {
+	if transferFeeBps == 0 {
+		return new(big.Int).Set(amount)
+	}
+
+	complement := feeComplement(transferFeeBps)
+	result := new(big.Int).Mul(amount, bpsDenominator)
+	result.Add(result, new(big.Int).Sub(complement, big.NewInt(1)))
+	return result.Quo(result, complement)
+}
@@ -0,0 +1,114 @@
+package uniswapV2Handler
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Fees already measured are served from the cache, so the handler never reaches the chain
+func TestTransferFeeBps(t *testing.T) {
+	network, err := ethHandler.GetEvmNetwork("ethereum_mainnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weth, err := ethHandler.GetToken(network.ChainId, "WETH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	discovered := ethHandler.RegisterToken(&ethHandler.Token{
+		ChainId:    network.ChainId,
+		Type:       ethHandler.ERC20,
+		Address:    "0x00000000000000000000000000000000000fee01",
+		Symbol:     "TAXED",
+		Decimals:   18,
+		Discovered: true,
+	})
+	t.Cleanup(func() { ethHandler.UnregisterToken(discovered) })
+	unknown := common.HexToAddress("0x00000000000000000000000000000000000fee02")
+
+	tests := []struct {
+		name        string
+		token       common.Address
+		detect      bool
+		measuredBps uint
+		want        uint
+	}{
+		{"shipped token uses the registry flag", common.HexToAddress(weth.Address), true, 700, 0},
+		{"discovered token is measured", common.HexToAddress(discovered.Address), true, 300, 300},
+		{"discovered token without detection", common.HexToAddress(discovered.Address), false, 300, 0},
+		{"unknown token is measured", unknown, true, 200, 200},
+		{"unknown token without detection", unknown, false, 200, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := &UniswapV2Handler{
+				EthHandler:         &ethHandler.EthHandler{Network: network},
+				DetectTransferFees: test.detect,
+				transferFees:       newTransferFeeCache(),
+			}
+			h.transferFees.fees[test.token] = test.measuredBps
+
+			got, err := h.transferFeeBps(test.token, common.Address{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != test.want {
+				t.Errorf("got %v bps, want %v bps", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTransferFeeCache(t *testing.T) {
+	cache := newTransferFeeCache()
+	slowToken := common.HexToAddress("0x01")
+	fastToken := common.HexToAddress("0x02")
+
+	// The slow measurement blocks until released, concurrent requests wait for it
+	release := make(chan struct{})
+	var measurements int32
+	results := make(chan uint, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			feeBps, _ := cache.get(slowToken, func() (uint, error) {
+				atomic.AddInt32(&measurements, 1)
+				<-release
+				return 300, nil
+			})
+			results <- feeBps
+		}()
+	}
+
+	// Another token is measured while the slow one is in progress
+	feeBps, err := cache.get(fastToken, func() (uint, error) { return 100, nil })
+	if err != nil || feeBps != 100 {
+		t.Fatalf("got %v (%v), want 100", feeBps, err)
+	}
+
+	close(release)
+	for i := 0; i < 3; i++ {
+		if feeBps := <-results; feeBps != 300 {
+			t.Errorf("got %v, want 300", feeBps)
+		}
+	}
+	if measurements := atomic.LoadInt32(&measurements); measurements != 1 {
+		t.Errorf("measured %v times, want once", measurements)
+	}
+
+	// Failed measurements are not cached
+	failing := common.HexToAddress("0x03")
+	if _, err := cache.get(failing, func() (uint, error) { return 0, errors.New("rpc failed") }); err == nil {
+		t.Fatal("expected an error")
+	}
+	if feeBps, err := cache.get(failing, func() (uint, error) { return 500, nil }); err != nil || feeBps != 500 {
+		t.Errorf("got %v (%v), want 500", feeBps, err)
+	}
+}
@@ -0,0 +1,86 @@
+package uniswapV2Handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	SushiswapPlatformName   = "sushiswap"
+	PancakeswapPlatformName = "pancakeswap"
+)
+
+// Deployment of Uniswap V2 or one of its forks.
+// Forks share the pair and router interfaces and differ by their contract
+// addresses, the init code hash of their pairs and their swap fee.
+type UniswapV2Config struct {
+	PlatformName   string
+	NetworkName    string
+	FactoryAddress string
+	RouterAddress  string
+	InitCodeHash   string // keccak256 of the pair creation code, used to compute pair addresses
+	FeeBps         uint   // swap fee in basis points
+}
+
+// TODO: Parse from json or config file
+var configsMap = map[string]*UniswapV2Config{
+	PlatformName: {
+		PlatformName:   PlatformName,
+		NetworkName:    "ethereum_goerli",
+		FactoryAddress: "0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f",
+		RouterAddress:  "0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D",
+		InitCodeHash:   "0x96e8ac4277198ff8b6f785478aa9a39f403cb768dd02cbee326c3e7da348845f",
+		FeeBps:         30,
+	},
+	SushiswapPlatformName: {
+		PlatformName:   SushiswapPlatformName,
+		NetworkName:    "ethereum_mainnet",
+		FactoryAddress: "0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac",
+		RouterAddress:  "0xd9e1cE17f2641f24aE83637ab66a2cca9C378B9F",
+		InitCodeHash:   "0xe18a34eb0e04b04f7a0ac29a6e80748dca96319b42c54d679cb821dca90c6303",
+		FeeBps:         30,
+	},
+	PancakeswapPlatformName: {
+		PlatformName:   PancakeswapPlatformName,
+		NetworkName:    "bsc_mainnet",
+		FactoryAddress: "0xcA143Ce32Fe78f1f7019d7d551a6402fC5350c73",
+		RouterAddress:  "0x10ED43C718714eb63d5aA57B78B54704E256024E",
+		InitCodeHash:   "0x00fb7f630766e6a796048ea87d01acd3068e8ff67d078148a3fa3f4a84f69bd5",
+		FeeBps:         25,
+	},
+}
+
+func GetConfig(platformName string) (*UniswapV2Config, error) {
+	platformName = strings.ToLower(platformName)
+	config, configFound := configsMap[platformName]
+	if !configFound {
+		return nil, fmt.Errorf("unknown uniswap v2 platform: %v", platformName)
+	}
+
+	return config, nil
+}
+
+func (c *UniswapV2Config) FactoryAddressForGeth() common.Address {
+	return common.HexToAddress(c.FactoryAddress)
+}
+
+func (c *UniswapV2Config) RouterAddressForGeth() common.Address {
+	return common.HexToAddress(c.RouterAddress)
+}
+
+func (c *UniswapV2Config) InitCodeHashForGeth() common.Hash {
+	return common.HexToHash(c.InitCodeHash)
+}
+
+// Returns the swap fee in percent
+func (c *UniswapV2Config) FeePercent() string {
+	return strconv.FormatFloat(float64(c.FeeBps)/100, 'f', -1, 64)
+}
+
+func (c *UniswapV2Config) String() string {
+	out := fmt.Sprintf("%v(network=%v)", c.PlatformName, c.NetworkName)
+	return out
+}
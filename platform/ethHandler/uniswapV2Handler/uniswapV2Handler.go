@@ -1,10 +1,10 @@
 package uniswapV2Handler
 
 import (
-	"context"
 	"fmt"
 	"math/big"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV2Pair"
@@ -19,19 +19,26 @@ import (
 
 const (
 	PlatformName      = "uniswap_v2"
-	swapFee           = "0.3" // in percent
 	txMineWaitTimeout = 5 * time.Minute
 )
 
-var router02Address = common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
-
 // Implements the Platform interface
 type UniswapV2Handler struct {
 	*ethHandler.EthHandler
-	SwapNativeETH bool // use native ETH as the input/output of a swap
-	SendSwapTx    bool // broadcast swap tx on blockchain
+	Config             *UniswapV2Config
+	PairDiscovery      *PairDiscovery
+	SwapNativeETH      bool          // use native ETH as the input/output of a swap
+	SendSwapTx         bool          // broadcast swap tx on blockchain
+	DetectTransferFees bool          // measure the transfer fee of tokens missing from the tokens registry
+	MaxHops            int           // maximum number of pairs in the path of a swap
+	StateCache         *ReserveCache // serves the prices of the cached pairs from memory while it runs
+	transferFees       *transferFeeCache
+
+	graphMu sync.Mutex
+	graph   *pairGraph // graph of the known pairs, built on the first path search
 }
 
+// Reserves of a pair, Token0 is the requested base and Token1 the quote
 type PairReserves struct {
 	Symbol             string
 	ChainId            ethHandler.ChainId
@@ -41,21 +48,27 @@ type PairReserves struct {
 	Reserve0           *big.Int
 	Reserve1           *big.Int
 	BlockTimestampLast time.Time
+	BlockNumber        uint64 // block the reserves were read at
 }
 
 func NewUniswapV2Handler() (*UniswapV2Handler, error) {
-	exchangeInfo := models.Exchange{
-		Type: models.Decentralized,
-		Name: PlatformName,
-	}
+	return NewUniswapV2ForkHandler(PlatformName)
+}
 
-	network, err := ethHandler.GetEvmNetwork("ethereum_goerli")
+// Returns a handler for Uniswap V2 or one of its forks registered in configsMap
+func NewUniswapV2ForkHandler(platformName string) (*UniswapV2Handler, error) {
+	config, err := GetConfig(platformName)
 	if err != nil {
 		panic(err)
 	}
 
-	if !isSupportedNetwork(network) {
-		err := fmt.Errorf("unsupported network: %v", network)
+	exchangeInfo := models.Exchange{
+		Type: models.Decentralized,
+		Name: config.PlatformName,
+	}
+
+	network, err := ethHandler.GetEvmNetwork(config.NetworkName)
+	if err != nil {
 		panic(err)
 	}
 
@@ -70,15 +83,30 @@ func NewUniswapV2Handler() (*UniswapV2Handler, error) {
 		panic(err)
 	}
 
-	return &UniswapV2Handler{
-		EthHandler:    ethHandlerInst,
-		SwapNativeETH: false,
-		SendSwapTx:    true,
-	}, nil
-}
+	factoryAddress := config.FactoryAddressForGeth()
+	indexPath, err := DefaultPairIndexPath(network.ChainId, factoryAddress)
+	if err != nil {
+		panic(err)
+	}
+
+	pairDiscovery, err := NewPairDiscovery(ethHandlerInst, factoryAddress, indexPath)
+	if err != nil {
+		panic(err)
+	}
 
-func isSupportedNetwork(network *ethHandler.EvmNetwork) bool {
-	return (network.ChainId == 1 || network.ChainId == 5) // Ethereum
+	handler := &UniswapV2Handler{
+		EthHandler:         ethHandlerInst,
+		Config:             config,
+		PairDiscovery:      pairDiscovery,
+		SwapNativeETH:      false,
+		SendSwapTx:         true,
+		DetectTransferFees: true,
+		MaxHops:            defaultMaxHops,
+		transferFees:       newTransferFeeCache(),
+	}
+	ethHandlerInst.OnTokenDiscovered = handler.prefetchTransferFee
+
+	return handler, nil
 }
 
 func (h *UniswapV2Handler) GetExchangeInfo() *models.Exchange {
@@ -89,29 +117,144 @@ func (h *UniswapV2Handler) TestConnection() (string, error) {
 	return h.EthHandler.TestConnection()
 }
 
+// Returns the prices of the registry pairs. Pairs missing from the state cache
+// are read in one multicall at the latest block.
 func (h *UniswapV2Handler) FetchTickerInfoAll() ([]models.TickerInfo, error) {
+	result, uncachedPairs := h.cachedPairPrices(h.registryPairs(), nil)
+	if len(uncachedPairs) == 0 {
+		return result, nil
+	}
+
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		panic(err)
+	}
+
+	tickers, err := h.fetchPairPrices(uncachedPairs, snapshot)
+	if err != nil {
+		panic(err)
+	}
+
+	return append(result, tickers...), nil
+}
+
+// Returns the prices of the registry pairs at the block of a snapshot
+func (h *UniswapV2Handler) FetchTickerInfoAllAt(snapshot *ethHandler.ChainSnapshot) ([]models.TickerInfo, error) {
+	result, uncachedPairs := h.cachedPairPrices(h.registryPairs(), snapshot)
+	tickers, err := h.fetchPairPrices(uncachedPairs, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result, tickers...), nil
+}
+
+// Reads the prices of pairs in one multicall at the block of a snapshot
+func (h *UniswapV2Handler) fetchPairPrices(pairs []*PairWrapper, snapshot *ethHandler.ChainSnapshot) ([]models.TickerInfo, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	reserves, err := h.fetchReservesBatch(pairs, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
 	var result []models.TickerInfo
+	for i, pair := range pairs {
+		if reserves[i] == nil {
+			return nil, fmt.Errorf("unable to fetch reserves of pair %v", pair)
+		}
 
-	for _, pair := range pairsMap {
-		if pair.ChainId == h.Network.ChainId {
-			ticker, err := h.getPairPrice(pair)
-			if err != nil {
-				panic(err)
-			}
-			result = append(result, ticker)
+		ticker, err := h.reservesTickerInfo(pair, reserves[i].Reserve0, reserves[i].Reserve1, reserves[i].BlockNumber)
+		if err != nil {
+			return nil, err
 		}
+		result = append(result, ticker)
 	}
 
 	return result, nil
 }
 
+// Returns the registry pairs of the handler's platform and network
+func (h *UniswapV2Handler) registryPairs() []*PairWrapper {
+	var pairs []*PairWrapper
+	for _, pair := range pairsMap {
+		if pair.PlatformName == h.Config.PlatformName && pair.ChainId == h.Network.ChainId {
+			pairs = append(pairs, pair)
+		}
+	}
+
+	return pairs
+}
+
+// Reads the reserves of pairs in one multicall at the block of a snapshot.
+// The reserves of a pair that fails the call are nil.
+func (h *UniswapV2Handler) fetchReservesBatch(pairs []*PairWrapper, snapshot *ethHandler.ChainSnapshot) ([]*CachedReserves, error) {
+	pairAbi, err := uniswapV2Pair.UniswapV2PairMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	multicaller, err := h.NewMulticaller()
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]*ethHandler.MulticallCall, len(pairs))
+	for i, pair := range pairs {
+		calls[i] = ethHandler.NewMulticallCall(common.HexToAddress(pair.PairAddress), pairAbi, "getReserves")
+		calls[i].AllowFailure = true
+	}
+
+	results, err := multicaller.Call(snapshot.CallOpts(), calls)
+	if err != nil {
+		return nil, err
+	}
+
+	reserves := make([]*CachedReserves, len(pairs))
+	for i, result := range results {
+		if result.Err != nil {
+			continue
+		}
+
+		reserves[i] = &CachedReserves{
+			Pair:        pairs[i],
+			Reserve0:    result.Outputs[0].(*big.Int),
+			Reserve1:    result.Outputs[1].(*big.Int),
+			BlockNumber: snapshot.Number(),
+		}
+	}
+
+	return reserves, nil
+}
+
 func (h *UniswapV2Handler) FetchTickerInfo(base string, quote string) (models.TickerInfo, error) {
-	pair, err := GetPair(h.Network.ChainId, base, quote)
+	pair, err := h.GetPair(base, quote)
+	if err != nil {
+		panic(err)
+	}
+
+	if ticker, found := h.cachedPairPrice(pair, nil); found {
+		return ticker, nil
+	}
+
+	snapshot, err := h.LatestSnapshot()
 	if err != nil {
 		panic(err)
 	}
 
-	return h.getPairPrice(pair)
+	return h.getPairPrice(pair, snapshot)
+}
+
+// Returns the price of a pair at the block of a snapshot
+func (h *UniswapV2Handler) FetchTickerInfoAt(snapshot *ethHandler.ChainSnapshot, base string, quote string) (models.TickerInfo, error) {
+	pair, err := h.GetPair(base, quote)
+	if err != nil {
+		return models.TickerInfo{}, err
+	}
+
+	return h.getPairPrice(pair, snapshot)
 }
 
 // Returns an instance for interacting with the IUniswapV2Pair smart contract
@@ -125,29 +268,45 @@ func (h *UniswapV2Handler) getPairInstance(address string) (*uniswapV2Pair.Unisw
 	return instance, nil
 }
 
-// Returns the current mid price of a pair
-func (h *UniswapV2Handler) getPairPrice(pair *PairWrapper) (models.TickerInfo, error) {
+// Returns the mid price of a pair at the block of a snapshot
+func (h *UniswapV2Handler) getPairPrice(pair *PairWrapper, snapshot *ethHandler.ChainSnapshot) (models.TickerInfo, error) {
+	if ticker, found := h.cachedPairPrice(pair, snapshot); found {
+		return ticker, nil
+	}
+
 	instance, err := h.getPairInstance(pair.PairAddress)
 	if err != nil {
 		panic(err)
 	}
 
-	token0, err := ethHandler.GetToken(pair.ChainId, pair.Token0Symbol)
+	reserves, err := instance.GetReserves(snapshot.CallOpts())
 	if err != nil {
 		panic(err)
 	}
 
-	token1, err := ethHandler.GetToken(pair.ChainId, pair.Token1Symbol)
+	return h.reservesTickerInfo(pair, reserves.Reserve0, reserves.Reserve1, snapshot.Number())
+}
+
+// Returns the mid price of a pair from its reserves
+func (h *UniswapV2Handler) reservesTickerInfo(
+	pair *PairWrapper,
+	reserve0 *big.Int,
+	reserve1 *big.Int,
+	blockNumber uint64,
+) (models.TickerInfo, error) {
+	reserve0, reserve1 = pair.orientReserves(reserve0, reserve1)
+
+	token0, err := ethHandler.GetToken(pair.ChainId, pair.Token0Symbol)
 	if err != nil {
 		panic(err)
 	}
 
-	reserves, err := instance.GetReserves(&bind.CallOpts{})
+	token1, err := ethHandler.GetToken(pair.ChainId, pair.Token1Symbol)
 	if err != nil {
 		panic(err)
 	}
 
-	token0Price := new(big.Rat).SetFrac(reserves.Reserve1, reserves.Reserve0)
+	token0Price := new(big.Rat).SetFrac(reserve1, reserve0)
 
 	// Determine the priceScalar based on the number of decimals in the
 	// base and quote tokens. Computed using the formula below:
@@ -167,19 +326,30 @@ func (h *UniswapV2Handler) getPairPrice(pair *PairWrapper) (models.TickerInfo, e
 
 	result := models.TickerInfo{
 		Symbol:         pair.Symbol(),
-		Base:           pair.Token0Symbol,
-		Quote:          pair.Token1Symbol,
+		Base:           string(token0.AssetId()),
+		Quote:          string(token1.AssetId()),
 		Price:          token0Price.FloatString(int(token1.Decimals)),
-		MakerComission: swapFee,
-		TakerComission: swapFee,
+		MakerComission: h.Config.FeePercent(),
+		TakerComission: h.Config.FeePercent(),
 		Timestamp:      time.Now(),
+		BlockNumber:    blockNumber,
 	}
 
 	return result, nil
 }
 
 func (h *UniswapV2Handler) FetchPairReserves(base string, quote string) (*PairReserves, error) {
-	pair, err := GetPair(h.Network.ChainId, base, quote)
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		panic(err)
+	}
+
+	return h.FetchPairReservesAt(snapshot, base, quote)
+}
+
+// Returns the reserves of a pair at the block of a snapshot
+func (h *UniswapV2Handler) FetchPairReservesAt(snapshot *ethHandler.ChainSnapshot, base string, quote string) (*PairReserves, error) {
+	pair, err := h.GetPair(base, quote)
 	if err != nil {
 		panic(err)
 	}
@@ -199,20 +369,22 @@ func (h *UniswapV2Handler) FetchPairReserves(base string, quote string) (*PairRe
 		panic(err)
 	}
 
-	reserves, err := instance.GetReserves(&bind.CallOpts{})
+	reserves, err := instance.GetReserves(snapshot.CallOpts())
 	if err != nil {
 		panic(err)
 	}
 
+	reserve0, reserve1 := pair.orientReserves(reserves.Reserve0, reserves.Reserve1)
 	result := &PairReserves{
 		Symbol:             pair.Symbol(),
 		ChainId:            pair.ChainId,
 		PairAddress:        pair.PairAddress,
 		Token0:             token0,
 		Token1:             token1,
-		Reserve0:           reserves.Reserve0,
-		Reserve1:           reserves.Reserve1,
+		Reserve0:           reserve0,
+		Reserve1:           reserve1,
 		BlockTimestampLast: time.Unix(int64(reserves.BlockTimestampLast), 0),
+		BlockNumber:        snapshot.Number(),
 	}
 
 	return result, nil
@@ -220,7 +392,7 @@ func (h *UniswapV2Handler) FetchPairReserves(base string, quote string) (*PairRe
 
 // Returns an instance for interacting with the IUniswapV2Router02 smart contract
 func (h *UniswapV2Handler) getRouter02Instance() (*uniswapV2Router02.UniswapV2Router02, error) {
-	instance, err := uniswapV2Router02.NewUniswapV2Router02(router02Address, h.Client)
+	instance, err := uniswapV2Router02.NewUniswapV2Router02(h.Config.RouterAddressForGeth(), h.Client)
 	if err != nil {
 		panic(err)
 	}
@@ -258,7 +430,7 @@ func (h *UniswapV2Handler) approveToken(wallet *ethHandler.Wallet, token *ethHan
 		panic(err)
 	}
 
-	err = tokenHandler.MaxApprove(wallet, router02Address, true)
+	err = tokenHandler.MaxApprove(wallet, h.Config.RouterAddressForGeth(), true)
 	if err != nil {
 		panic(err)
 	}
@@ -272,38 +444,42 @@ func (h *UniswapV2Handler) ExecuteOrder(order models.Order) error {
 		panic(err)
 	}
 
-	chainId := big.NewInt(int64(h.Network.ChainId))
-	auth, err := bind.NewKeyedTransactorWithChainID(wallet.PrivateKey, chainId)
+	routerInstance, err := h.getRouter02Instance()
 	if err != nil {
 		panic(err)
 	}
 
-	routerInstance, err := h.getRouter02Instance()
+	baseToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, order.Base)
 	if err != nil {
 		panic(err)
 	}
 
-	baseSymbol, quoteSymbol := NormalizePairTokens(order.Base, order.Quote)
-	baseToken, err := ethHandler.GetToken(h.Network.ChainId, baseSymbol)
+	quoteToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, order.Quote)
 	if err != nil {
 		panic(err)
 	}
 
-	quoteToken, err := ethHandler.GetToken(h.Network.ChainId, quoteSymbol)
+	wethToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, h.Network.NativeCurrency.Symbol)
 	if err != nil {
 		panic(err)
 	}
 
-	wethToken, err := ethHandler.GetToken(h.Network.ChainId, "WETH")
+	wethAddress := wethToken.AddressForGeth()
+	path, inputToken, err := h.getOrderPath(baseToken, quoteToken, order.Action)
 	if err != nil {
 		panic(err)
 	}
 
-	wethAddress := wethToken.AddressForGeth()
-	path, inputToken, err := h.getOrderPath(baseToken, quoteToken, order.Action)
+	routeAmount := order.LiqPoolAmountIn
+	if order.SwapKind == models.ExactOutput {
+		routeAmount = order.LiqPoolAmountOut
+	}
+
+	bestQuote, err := h.FindBestPath(path[0], path[len(path)-1], routeAmount, order.SwapKind)
 	if err != nil {
 		panic(err)
 	}
+	path = bestQuote.Path
 
 	if !h.SwapNativeETH || path[0] != wethAddress {
 		// TODO: Pre-approve tokens on init
@@ -313,68 +489,48 @@ func (h *UniswapV2Handler) ExecuteOrder(order models.Order) error {
 		}
 	}
 
-	// TODO: Maybe keep track of nonce locally
-	nonce, err := h.Client.PendingNonceAt(context.Background(), wallet.Address)
+	// The nonce is read after the approve transaction
+	auth, err := h.TransactOpts(wallet, nil, h.SendSwapTx)
 	if err != nil {
 		panic(err)
 	}
 
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = nil
-	auth.NoSend = !h.SendSwapTx
-
-	// TODO: Get gas estimates from the gasEstimator module
-	auth.GasPrice = nil
-	auth.GasFeeCap = nil
-	auth.GasTipCap = nil
-	auth.GasLimit = uint64(0) // in units (300000 should be a good upper bound)
-
 	deadline := big.NewInt(time.Now().Add(order.Deadline).Unix())
-	var tx *types.Transaction = nil
+	feeOnTransfer, err := h.pathHasTransferFee(path)
+	if err != nil {
+		panic(err)
+	}
 
-	if h.SwapNativeETH && path[0] == wethAddress {
-		auth.Value = order.LiqPoolAmountIn
-		tx, err = routerInstance.SwapExactETHForTokens(
-			auth,
-			order.LiqPoolAmountOut,
-			path,
-			wallet.Address,
-			deadline)
+	params := &swapParams{
+		order:         &order,
+		path:          path,
+		to:            wallet.Address,
+		deadline:      deadline,
+		nativeIn:      h.SwapNativeETH && path[0] == wethAddress,
+		nativeOut:     h.SwapNativeETH && path[len(path)-1] == wethAddress,
+		feeOnTransfer: feeOnTransfer,
+	}
 
-		if err != nil {
-			panic(err)
-		}
-	} else if h.SwapNativeETH && path[len(path)-1] == wethAddress {
-		tx, err = routerInstance.SwapExactTokensForETH(
-			auth,
-			order.LiqPoolAmountIn,
-			order.LiqPoolAmountOut,
-			path,
-			wallet.Address,
-			deadline)
+	var tx *types.Transaction = nil
 
-		if err != nil {
-			panic(err)
-		}
-	} else {
-		tx, err = routerInstance.SwapExactTokensForTokens(
-			auth,
-			order.LiqPoolAmountIn,
-			order.LiqPoolAmountOut,
-			path,
-			wallet.Address,
-			deadline)
+	switch order.SwapKind {
+	case models.ExactInput:
+		tx, err = h.swapExactInput(routerInstance, auth, params)
+	case models.ExactOutput:
+		tx, err = h.swapExactOutput(routerInstance, auth, params)
+	default:
+		err = fmt.Errorf("unsupported swap kind %v", order.SwapKind)
+	}
 
-		if err != nil {
-			panic(err)
-		}
+	if err != nil {
+		panic(err)
 	}
 
 	if tx == nil {
 		panic("failed to prepare transaction")
 	}
 
-	fmt.Printf("\n[[ %v/%v %v tx ]]\n", order.Base, order.Quote, order.Action.String())
+	fmt.Printf("\n[[ %v/%v %v %v tx ]]\n", order.Base, order.Quote, order.Action.String(), order.SwapKind.String())
 	fmt.Printf("tx hash: %s\n", tx.Hash())
 	fmt.Printf("gas priority fee: %v\n", tx.GasTipCap())
 	fmt.Printf("gas max fee: %v\n", tx.GasFeeCap())
@@ -392,6 +548,124 @@ func (h *UniswapV2Handler) ExecuteOrder(order models.Order) error {
 	return nil
 }
 
+type swapParams struct {
+	order         *models.Order
+	path          []common.Address
+	to            common.Address
+	deadline      *big.Int
+	nativeIn      bool // swap native ETH instead of WETH as the input
+	nativeOut     bool // swap native ETH instead of WETH as the output
+	feeOnTransfer bool // a token of the path charges a fee on transfers
+}
+
+// Spends exactly LiqPoolAmountIn and receives at least LiqPoolAmountOut.
+// Fee-on-transfer tokens are swapped with the SupportingFeeOnTransferTokens variants,
+// which check the balance received instead of the amounts computed from the reserves.
+func (h *UniswapV2Handler) swapExactInput(
+	routerInstance *uniswapV2Router02.UniswapV2Router02,
+	auth *bind.TransactOpts,
+	params *swapParams,
+) (*types.Transaction, error) {
+	order := params.order
+	if params.nativeIn {
+		auth.Value = order.LiqPoolAmountIn
+		if params.feeOnTransfer {
+			return routerInstance.SwapExactETHForTokensSupportingFeeOnTransferTokens(
+				auth,
+				order.LiqPoolAmountOut,
+				params.path,
+				params.to,
+				params.deadline)
+		}
+
+		return routerInstance.SwapExactETHForTokens(
+			auth,
+			order.LiqPoolAmountOut,
+			params.path,
+			params.to,
+			params.deadline)
+	}
+
+	if params.nativeOut {
+		if params.feeOnTransfer {
+			return routerInstance.SwapExactTokensForETHSupportingFeeOnTransferTokens(
+				auth,
+				order.LiqPoolAmountIn,
+				order.LiqPoolAmountOut,
+				params.path,
+				params.to,
+				params.deadline)
+		}
+
+		return routerInstance.SwapExactTokensForETH(
+			auth,
+			order.LiqPoolAmountIn,
+			order.LiqPoolAmountOut,
+			params.path,
+			params.to,
+			params.deadline)
+	}
+
+	if params.feeOnTransfer {
+		return routerInstance.SwapExactTokensForTokensSupportingFeeOnTransferTokens(
+			auth,
+			order.LiqPoolAmountIn,
+			order.LiqPoolAmountOut,
+			params.path,
+			params.to,
+			params.deadline)
+	}
+
+	return routerInstance.SwapExactTokensForTokens(
+		auth,
+		order.LiqPoolAmountIn,
+		order.LiqPoolAmountOut,
+		params.path,
+		params.to,
+		params.deadline)
+}
+
+// Receives exactly LiqPoolAmountOut and spends at most LiqPoolAmountIn
+func (h *UniswapV2Handler) swapExactOutput(
+	routerInstance *uniswapV2Router02.UniswapV2Router02,
+	auth *bind.TransactOpts,
+	params *swapParams,
+) (*types.Transaction, error) {
+	if params.feeOnTransfer {
+		return nil, ErrExactOutputTransferFee
+	}
+
+	order := params.order
+	if params.nativeIn {
+		// The router refunds the ETH which is not needed for the swap
+		auth.Value = order.LiqPoolAmountIn
+		return routerInstance.SwapETHForExactTokens(
+			auth,
+			order.LiqPoolAmountOut,
+			params.path,
+			params.to,
+			params.deadline)
+	}
+
+	if params.nativeOut {
+		return routerInstance.SwapTokensForExactETH(
+			auth,
+			order.LiqPoolAmountOut,
+			order.LiqPoolAmountIn,
+			params.path,
+			params.to,
+			params.deadline)
+	}
+
+	return routerInstance.SwapTokensForExactTokens(
+		auth,
+		order.LiqPoolAmountOut,
+		order.LiqPoolAmountIn,
+		params.path,
+		params.to,
+		params.deadline)
+}
+
 func (h *UniswapV2Handler) String() string {
 	return h.ExchangeInfo.Name
 }
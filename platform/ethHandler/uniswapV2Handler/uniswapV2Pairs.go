@@ -2,71 +2,169 @@ package uniswapV2Handler
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// Pair of a base and a quote token.
+// Inverted is set when the base is token1 of the pair contract, the reserves read
+// from the contract are then swapped so that prices stay in the requested orientation.
 type PairWrapper struct {
+	PlatformName string
 	ChainId      ethHandler.ChainId
 	PairAddress  string
 	Token0Symbol string
 	Token1Symbol string
+	Inverted     bool
 }
 
 // TODO: Parse from json or config file
 var pairsMap = map[string]*PairWrapper{
-	genPairsMapKey(1, "USDC", "WETH"): {
+	genPairsMapKey(PlatformName, 1, "USDC", "WETH"): {
+		PlatformName: PlatformName,
 		ChainId:      1,
 		PairAddress:  "0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc",
 		Token0Symbol: "USDC",
 		Token1Symbol: "WETH",
 	},
-	genPairsMapKey(1, "LINK", "WETH"): {
+	genPairsMapKey(PlatformName, 1, "LINK", "WETH"): {
+		PlatformName: PlatformName,
 		ChainId:      1,
 		PairAddress:  "0xa2107FA5B38d9bbd2C461D6EDf11B11A50F6b974",
 		Token0Symbol: "LINK",
 		Token1Symbol: "WETH",
 	},
-	genPairsMapKey(5, "USDC", "WETH"): {
+	genPairsMapKey(PlatformName, 5, "USDC", "WETH"): {
+		PlatformName: PlatformName,
 		ChainId:      5,
 		PairAddress:  "0x647595535c370F6092C6daE9D05a7Ce9A8819F37",
 		Token0Symbol: "USDC",
 		Token1Symbol: "WETH",
 	},
-	genPairsMapKey(5, "LINK", "WETH"): {
+	genPairsMapKey(PlatformName, 5, "LINK", "WETH"): {
+		PlatformName: PlatformName,
 		ChainId:      5,
 		PairAddress:  "0x32bE40dC4Db907aCf18773bfC81F1bFFA92B77c2",
 		Token0Symbol: "LINK",
 		Token1Symbol: "WETH",
 	},
+	genPairsMapKey(SushiswapPlatformName, 1, "USDC", "WETH"): {
+		PlatformName: SushiswapPlatformName,
+		ChainId:      1,
+		PairAddress:  "0x397FF1542f962076d0BFE58eA045FfA2d347ACa0",
+		Token0Symbol: "USDC",
+		Token1Symbol: "WETH",
+	},
+	genPairsMapKey(SushiswapPlatformName, 1, "LINK", "WETH"): {
+		PlatformName: SushiswapPlatformName,
+		ChainId:      1,
+		PairAddress:  "0xC40D16476380e4037e6b1A2594cAF6a6cc8Da967",
+		Token0Symbol: "LINK",
+		Token1Symbol: "WETH",
+	},
+	genPairsMapKey(PancakeswapPlatformName, 56, "USDT", "WBNB"): {
+		PlatformName: PancakeswapPlatformName,
+		ChainId:      56,
+		PairAddress:  "0x16b9a82891338f9bA80E2D6970FddA79D1eb0daE",
+		Token0Symbol: "USDT",
+		Token1Symbol: "WBNB",
+	},
 }
 
-func genPairsMapKey(chainId ethHandler.ChainId, base string, quote string) string {
-	return fmt.Sprintf("%v|%v/%v", chainId, base, quote)
+func genPairsMapKey(platformName string, chainId ethHandler.ChainId, base string, quote string) string {
+	return fmt.Sprintf("%v|%v|%v/%v", platformName, chainId, base, quote)
+}
+
+// Resolves the canonical base and quote assets to the pair of their tokens.
+// Native ETH resolves to WETH through the asset registry.
+// Pairs missing from the registry are resolved by computing their CREATE2 address.
+func GetPair(platformName string, chainId ethHandler.ChainId, base string, quote string) (*PairWrapper, error) {
+	baseToken, quoteToken, err := getPairTokens(chainId, base, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if pair, found := lookupRegistryPair(platformName, baseToken, quoteToken); found {
+		return pair, nil
+	}
+
+	config, err := GetConfig(platformName)
+	if err != nil {
+		return nil, err
+	}
+
+	pairAddress := config.ComputePairAddress(baseToken.AddressForGeth(), quoteToken.AddressForGeth())
+	return newPairWrapper(platformName, baseToken, quoteToken, pairAddress), nil
 }
 
-func NormalizePairTokens(base string, quote string) (string, string) {
-	// Wrap native ETH
-	if base == "ETH" {
-		base = "WETH"
+// Resolves the canonical base and quote assets to the pair of their tokens.
+// Pairs missing from the registry are looked up in the pair discovery index,
+// then queried from the factory through getPair.
+func (h *UniswapV2Handler) GetPair(base string, quote string) (*PairWrapper, error) {
+	if h.PairDiscovery == nil {
+		return GetPair(h.Config.PlatformName, h.Network.ChainId, base, quote)
 	}
 
-	if quote == "ETH" {
-		quote = "WETH"
+	baseToken, quoteToken, err := getPairTokens(h.Network.ChainId, base, quote)
+	if err != nil {
+		return nil, err
 	}
 
-	return base, quote
+	if pair, found := lookupRegistryPair(h.Config.PlatformName, baseToken, quoteToken); found {
+		return pair, nil
+	}
+
+	indexedPair, err := h.PairDiscovery.ResolvePair(baseToken.AddressForGeth(), quoteToken.AddressForGeth())
+	if err != nil {
+		return nil, err
+	}
+
+	return newPairWrapper(h.Config.PlatformName, baseToken, quoteToken, indexedPair.Address), nil
+}
+
+func getPairTokens(chainId ethHandler.ChainId, base string, quote string) (*ethHandler.Token, *ethHandler.Token, error) {
+	baseToken, err := ethHandler.GetTokenForAsset(chainId, base)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quoteToken, err := ethHandler.GetTokenForAsset(chainId, quote)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return baseToken, quoteToken, nil
+}
+
+func lookupRegistryPair(platformName string, baseToken *ethHandler.Token, quoteToken *ethHandler.Token) (*PairWrapper, bool) {
+	key := genPairsMapKey(platformName, baseToken.ChainId, baseToken.Symbol, quoteToken.Symbol)
+	pair, found := pairsMap[key]
+	return pair, found
+}
+
+// Returns the pair at an address in the orientation of the requested base and quote
+func newPairWrapper(platformName string, base *ethHandler.Token, quote *ethHandler.Token, pairAddress common.Address) *PairWrapper {
+	token0Address, _ := ethHandler.SortTokens(base.AddressForGeth(), quote.AddressForGeth())
+
+	return &PairWrapper{
+		PlatformName: platformName,
+		ChainId:      base.ChainId,
+		PairAddress:  pairAddress.Hex(),
+		Token0Symbol: base.Symbol,
+		Token1Symbol: quote.Symbol,
+		Inverted:     token0Address != base.AddressForGeth(),
+	}
 }
 
-func GetPair(chainId ethHandler.ChainId, base string, quote string) (*PairWrapper, error) {
-	base, quote = NormalizePairTokens(base, quote)
-	key := genPairsMapKey(chainId, base, quote)
-	pair, pairFound := pairsMap[key]
-	if !pairFound {
-		return nil, fmt.Errorf("unknown pair with chainId=%v base=%v quote=%v", chainId, base, quote)
+// Orients the reserves of the pair contract as the reserves of the base and the quote
+func (p *PairWrapper) orientReserves(reserve0 *big.Int, reserve1 *big.Int) (*big.Int, *big.Int) {
+	if p.Inverted {
+		return reserve1, reserve0
 	}
 
-	return pair, nil
+	return reserve0, reserve1
 }
 
 func (p *PairWrapper) Symbol() string {
@@ -74,6 +172,6 @@ func (p *PairWrapper) Symbol() string {
 }
 
 func (p *PairWrapper) String() string {
-	out := fmt.Sprintf("%v(chainId=%v)", p.Symbol(), p.ChainId)
+	out := fmt.Sprintf("%v(platform=%v chainId=%v)", p.Symbol(), p.PlatformName, p.ChainId)
 	return out
 }
@@ -0,0 +1,71 @@
+package uniswapV2Handler
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+)
+
+// Pairs missing from the registry keep the requested orientation, whatever the order of their tokens
+func TestPairOrientation(t *testing.T) {
+	config, err := GetConfig(PlatformName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usdc, err := ethHandler.GetToken(1, "USDC")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weth, err := ethHandler.GetToken(1, "WETH")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &UniswapV2Handler{Config: config}
+
+	// Reserves of the pair contract, whose token0 is USDC
+	reserveUsdc := big.NewInt(3000000000000)
+	reserveWeth := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+
+	tests := []struct {
+		base     *ethHandler.Token
+		quote    *ethHandler.Token
+		inverted bool
+		price    string
+	}{
+		{usdc, weth, false, "0.000333333333333333"},
+		{weth, usdc, true, "3000.000000"},
+	}
+
+	for _, test := range tests {
+		symbol := test.base.Symbol + "/" + test.quote.Symbol
+		t.Run(symbol, func(t *testing.T) {
+			pair, err := GetPair(PlatformName, 1, test.base.Symbol, test.quote.Symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if pair.Symbol() != symbol || pair.Inverted != test.inverted {
+				t.Fatalf("got %v inverted=%v, want %v inverted=%v", pair.Symbol(), pair.Inverted, symbol, test.inverted)
+			}
+			if want := pairsMap[genPairsMapKey(PlatformName, 1, "USDC", "WETH")].PairAddress; pair.PairAddress != want {
+				t.Errorf("got pair %v, want %v", pair.PairAddress, want)
+			}
+
+			ticker, err := h.reservesTickerInfo(pair, reserveUsdc, reserveWeth, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ticker.Base != string(test.base.AssetId()) || ticker.Quote != string(test.quote.AssetId()) {
+				t.Errorf("got %v/%v, want %v/%v", ticker.Base, ticker.Quote, test.base.AssetId(), test.quote.AssetId())
+			}
+			if ticker.Price != test.price {
+				t.Errorf("got price %v, want %v", ticker.Price, test.price)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package uniswapV2Handler
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV2Pair"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	bpsDenominator = big.NewInt(10000)
+
+	ErrInsufficientInputAmount  = errors.New("UniswapV2Library: INSUFFICIENT_INPUT_AMOUNT")
+	ErrInsufficientOutputAmount = errors.New("UniswapV2Library: INSUFFICIENT_OUTPUT_AMOUNT")
+	ErrInsufficientLiquidity    = errors.New("UniswapV2Library: INSUFFICIENT_LIQUIDITY")
+	ErrInvalidPath              = errors.New("UniswapV2Library: INVALID_PATH")
+)
+
+// Reserves of a pair oriented in the direction of a swap hop
+type HopReserves struct {
+	PairAddress    common.Address
+	TokenIn        common.Address
+	TokenOut       common.Address
+	ReserveIn      *big.Int
+	ReserveOut     *big.Int
+	TokenInFeeBps  uint // transfer fee charged when the input is sent to the pair
+	TokenOutFeeBps uint // transfer fee charged when the output is sent by the pair
+}
+
+// Returns true if a token of the path charges a fee on transfers
+func HasTransferFee(hops []*HopReserves) bool {
+	for _, hop := range hops {
+		if hop.TokenInFeeBps > 0 || hop.TokenOutFeeBps > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Result of quoting a swap along a path.
+// Prices are expressed in raw token units (output wei per input wei).
+type Quote struct {
+	Path           []common.Address
+	Amounts        []*big.Int // amount of every token sent along the path, as returned by the router
+	AmountIn       *big.Int
+	AmountOut      *big.Int
+	MidPrice       *big.Rat // price of the path before the swap
+	EffectivePrice *big.Rat // AmountOut / AmountIn
+	PriceImpact    *big.Rat // 1 - EffectivePrice / MidPrice, includes the swap and transfer fees
+}
+
+// Given an input amount of an asset and pair reserves, returns the maximum output amount of the other asset.
+// Identical to UniswapV2Library.getAmountOut with the fee generalized to basis points (30 => 997/1000).
+func GetAmountOut(amountIn *big.Int, reserveIn *big.Int, reserveOut *big.Int, feeBps uint) (*big.Int, error) {
+	if amountIn.Sign() <= 0 {
+		return nil, ErrInsufficientInputAmount
+	}
+
+	if reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	amountInWithFee := new(big.Int).Mul(amountIn, feeComplement(feeBps))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Mul(reserveIn, bpsDenominator)
+	denominator.Add(denominator, amountInWithFee)
+
+	return numerator.Quo(numerator, denominator), nil
+}
+
+// Given an output amount of an asset and pair reserves, returns a required input amount of the other asset.
+// Identical to UniswapV2Library.getAmountIn with the fee generalized to basis points.
+func GetAmountIn(amountOut *big.Int, reserveIn *big.Int, reserveOut *big.Int, feeBps uint) (*big.Int, error) {
+	if amountOut.Sign() <= 0 {
+		return nil, ErrInsufficientOutputAmount
+	}
+
+	if reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	// The library reverts on the underflow of reserveOut - amountOut
+	if amountOut.Cmp(reserveOut) >= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	numerator := new(big.Int).Mul(reserveIn, amountOut)
+	numerator.Mul(numerator, bpsDenominator)
+	denominator := new(big.Int).Sub(reserveOut, amountOut)
+	denominator.Mul(denominator, feeComplement(feeBps))
+
+	amountIn := numerator.Quo(numerator, denominator)
+	return amountIn.Add(amountIn, big.NewInt(1)), nil
+}
+
+// Performs chained getAmountOut calculations on any number of pairs.
+// Transfer fees reduce the amount received by every pair and by the recipient,
+// the last amount is the amount received by the recipient.
+func GetAmountsOut(amountIn *big.Int, hops []*HopReserves, feeBps uint) ([]*big.Int, error) {
+	if len(hops) == 0 {
+		return nil, ErrInvalidPath
+	}
+
+	amounts := make([]*big.Int, len(hops)+1)
+	amounts[0] = new(big.Int).Set(amountIn)
+	for i, hop := range hops {
+		amountReceived := applyTransferFee(amounts[i], hop.TokenInFeeBps)
+		amountOut, err := GetAmountOut(amountReceived, hop.ReserveIn, hop.ReserveOut, feeBps)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i+1] = amountOut
+	}
+
+	lastHop := hops[len(hops)-1]
+	amounts[len(hops)] = applyTransferFee(amounts[len(hops)], lastHop.TokenOutFeeBps)
+	return amounts, nil
+}
+
+// Performs chained getAmountIn calculations on any number of pairs, starting from the last hop.
+// Transfer fees are added on top of the amounts so that every pair and the recipient
+// receive at least the required amount.
+func GetAmountsIn(amountOut *big.Int, hops []*HopReserves, feeBps uint) ([]*big.Int, error) {
+	if len(hops) == 0 {
+		return nil, ErrInvalidPath
+	}
+
+	lastHop := hops[len(hops)-1]
+	amounts := make([]*big.Int, len(hops)+1)
+	amounts[len(hops)] = new(big.Int).Set(amountOut)
+	amountRequired := reverseTransferFee(amountOut, lastHop.TokenOutFeeBps)
+	for i := len(hops) - 1; i >= 0; i-- {
+		amountIn, err := GetAmountIn(amountRequired, hops[i].ReserveIn, hops[i].ReserveOut, feeBps)
+		if err != nil {
+			return nil, err
+		}
+
+		amountRequired = reverseTransferFee(amountIn, hops[i].TokenInFeeBps)
+		amounts[i] = amountRequired
+	}
+
+	return amounts, nil
+}
+
+// Builds the quote of a swap from the amounts along the path and the reserves of its hops
+func NewQuote(amounts []*big.Int, hops []*HopReserves) *Quote {
+	path := make([]common.Address, 0, len(hops)+1)
+	midPrice := big.NewRat(1, 1)
+	for i, hop := range hops {
+		if i == 0 {
+			path = append(path, hop.TokenIn)
+		}
+		path = append(path, hop.TokenOut)
+		midPrice.Mul(midPrice, new(big.Rat).SetFrac(hop.ReserveOut, hop.ReserveIn))
+	}
+
+	amountIn := amounts[0]
+	amountOut := amounts[len(amounts)-1]
+	effectivePrice := new(big.Rat).SetFrac(amountOut, amountIn)
+
+	priceImpact := new(big.Rat).Quo(effectivePrice, midPrice)
+	priceImpact.Sub(big.NewRat(1, 1), priceImpact)
+
+	return &Quote{
+		Path:           path,
+		Amounts:        amounts,
+		AmountIn:       amountIn,
+		AmountOut:      amountOut,
+		MidPrice:       midPrice,
+		EffectivePrice: effectivePrice,
+		PriceImpact:    priceImpact,
+	}
+}
+
+// Returns the price impact in basis points, rounded down
+func (q *Quote) PriceImpactBps() int64 {
+	bps := new(big.Rat).Mul(q.PriceImpact, new(big.Rat).SetInt(bpsDenominator))
+	return new(big.Int).Quo(bps.Num(), bps.Denom()).Int64()
+}
+
+// Returns the amounts of a swap of an exact input along a path.
+// Identical to router.getAmountsOut for tokens without transfer fees.
+func (h *UniswapV2Handler) QuoteExactInput(amountIn *big.Int, path []common.Address) (*Quote, error) {
+	hops, err := h.FetchHopReserves(path)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts, err := GetAmountsOut(amountIn, hops, h.Config.FeeBps)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewQuote(amounts, hops), nil
+}
+
+// Returns the amounts of a swap of an exact output along a path.
+// Identical to router.getAmountsIn for tokens without transfer fees.
+func (h *UniswapV2Handler) QuoteExactOutput(amountOut *big.Int, path []common.Address) (*Quote, error) {
+	hops, err := h.FetchHopReserves(path)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts, err := GetAmountsIn(amountOut, hops, h.Config.FeeBps)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewQuote(amounts, hops), nil
+}
+
+// Fetches the reserves of every pair along a path at the latest block, oriented in the direction of the swap
+func (h *UniswapV2Handler) FetchHopReserves(path []common.Address) ([]*HopReserves, error) {
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.FetchHopReservesAt(snapshot, path)
+}
+
+// Fetches the reserves of every pair along a path at the block of a snapshot
+func (h *UniswapV2Handler) FetchHopReservesAt(snapshot *ethHandler.ChainSnapshot, path []common.Address) ([]*HopReserves, error) {
+	if len(path) < 2 {
+		return nil, ErrInvalidPath
+	}
+
+	keys := make([]hopKey, len(path)-1)
+	for i := 0; i < len(path)-1; i++ {
+		keys[i] = hopKey{path[i], path[i+1]}
+	}
+
+	reserves, err := h.fetchHopsReserves(snapshot, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	hops := make([]*HopReserves, len(keys))
+	for i, key := range keys {
+		hops[i], err = reserves.get(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hops, nil
+}
+
+// Pair of a swap from tokenIn to tokenOut
+type hopKey struct {
+	tokenIn  common.Address
+	tokenOut common.Address
+}
+
+// Reserves of hops read at the same block, or the error reading them
+type hopsReserves struct {
+	hops map[hopKey]*HopReserves
+	errs map[hopKey]error
+}
+
+func (r *hopsReserves) get(key hopKey) (*HopReserves, error) {
+	if err, found := r.errs[key]; found {
+		return nil, err
+	}
+
+	hop, found := r.hops[key]
+	if !found {
+		return nil, fmt.Errorf("reserves of %v>%v were not fetched", key.tokenIn, key.tokenOut)
+	}
+
+	return hop, nil
+}
+
+// Fetches the reserves of the pairs of hops at the block of a snapshot, oriented in the direction of each hop.
+// Reserves cached at that block are served by the state cache, the others are read in a single multicall.
+// A pair whose reserves cannot be read only fails its own hops.
+func (h *UniswapV2Handler) fetchHopsReserves(snapshot *ethHandler.ChainSnapshot, keys []hopKey) (*hopsReserves, error) {
+	pairAbi, err := uniswapV2Pair.UniswapV2PairMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	reserves := &hopsReserves{
+		hops: make(map[hopKey]*HopReserves),
+		errs: make(map[hopKey]error),
+	}
+
+	var calls []*ethHandler.MulticallCall
+	var calledHops []*HopReserves
+	for _, key := range keys {
+		if _, found := reserves.hops[key]; found {
+			continue
+		}
+
+		pairAddress := h.Config.ComputePairAddress(key.tokenIn, key.tokenOut)
+		hop := &HopReserves{PairAddress: pairAddress, TokenIn: key.tokenIn, TokenOut: key.tokenOut}
+		reserves.hops[key] = hop
+
+		if h.StateCache != nil {
+			cached := h.StateCache.Reserves(pairAddress)
+			if cached != nil && cached.BlockNumber == snapshot.Number() {
+				hop.setReserves(cached.Reserve0, cached.Reserve1)
+				continue
+			}
+		}
+
+		call := ethHandler.NewMulticallCall(pairAddress, pairAbi, "getReserves")
+		call.AllowFailure = true
+		calls = append(calls, call)
+		calledHops = append(calledHops, hop)
+	}
+
+	if len(calls) > 0 {
+		multicaller, err := h.NewMulticaller()
+		if err != nil {
+			return nil, err
+		}
+
+		results, err := multicaller.Call(snapshot.CallOpts(), calls)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, result := range results {
+			hop := calledHops[i]
+			if result.Err != nil {
+				key := hopKey{hop.TokenIn, hop.TokenOut}
+				reserves.errs[key] = fmt.Errorf("unable to fetch reserves of pair %v: %w", hop.PairAddress, result.Err)
+				delete(reserves.hops, key)
+				continue
+			}
+
+			hop.setReserves(result.Outputs[0].(*big.Int), result.Outputs[1].(*big.Int))
+		}
+	}
+
+	for _, hop := range reserves.hops {
+		hop.TokenInFeeBps, err = h.transferFeeBps(hop.TokenIn, hop.PairAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		hop.TokenOutFeeBps, err = h.transferFeeBps(hop.TokenOut, hop.PairAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reserves, nil
+}
+
+// Orients the reserves of the pair, given in the order of its sorted tokens, in the direction of the hop
+func (hop *HopReserves) setReserves(reserve0 *big.Int, reserve1 *big.Int) {
+	hop.ReserveIn, hop.ReserveOut = reserve0, reserve1
+	if token0, _ := ethHandler.SortTokens(hop.TokenIn, hop.TokenOut); token0 != hop.TokenIn {
+		hop.ReserveIn, hop.ReserveOut = reserve1, reserve0
+	}
+}
+
+// 10000 - feeBps, the share of the input amount which is swapped
+func feeComplement(feeBps uint) *big.Int {
+	return big.NewInt(int64(10000 - feeBps))
+}
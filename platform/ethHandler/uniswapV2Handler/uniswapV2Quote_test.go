@@ -0,0 +1,291 @@
+package uniswapV2Handler
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Vectors computed with the integer arithmetic of UniswapV2Library (997/1000)
+// and PancakeLibrary (9975/10000)
+
+// Reserves of the USDC/WETH and USDC/LINK pairs used by the vectors
+var (
+	reserveUsdc     = bigInt("52311456712345")
+	reserveWeth     = bigInt("39876543210987654321098")
+	reserveUsdcLink = bigInt("8123456789012345")
+	reserveLink     = bigInt("1234567890123456789012345")
+)
+
+func bigInt(s string) *big.Int {
+	value, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid integer " + s)
+	}
+
+	return value
+}
+
+func TestGetAmountOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		amountIn   *big.Int
+		reserveIn  *big.Int
+		reserveOut *big.Int
+		feeBps     uint
+		want       *big.Int
+	}{
+		{"1 WETH to USDC, 30 bps", bigInt("1000000000000000000"), reserveWeth, reserveUsdc, 30, bigInt("1307867086")},
+		{"1 WETH to USDC, 25 bps", bigInt("1000000000000000000"), reserveWeth, reserveUsdc, 25, bigInt("1308522971")},
+		{"1000 USDC to WETH, 30 bps", bigInt("1000000000"), reserveUsdc, reserveWeth, 30, bigInt("759989462546900594")},
+		{"1000 USDC to WETH, 25 bps", bigInt("1000000000"), reserveUsdc, reserveWeth, 25, bigInt("760370593425024402")},
+		{"dust rounds to zero", big.NewInt(1), big.NewInt(1000000), big.NewInt(1000000), 30, big.NewInt(0)},
+		{"input larger than the reserves", big.NewInt(12345), big.NewInt(1000), big.NewInt(1000), 30, big.NewInt(924)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GetAmountOut(test.amountIn, test.reserveIn, test.reserveOut, test.feeBps)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Cmp(test.want) != 0 {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetAmountIn(t *testing.T) {
+	tests := []struct {
+		name       string
+		amountOut  *big.Int
+		reserveIn  *big.Int
+		reserveOut *big.Int
+		feeBps     uint
+		want       *big.Int
+	}{
+		{"1311 USDC for WETH, 30 bps", bigInt("1311000000"), reserveWeth, reserveUsdc, 30, bigInt("1002395497070268630")},
+		{"1311 USDC for WETH, 25 bps", bigInt("1311000000"), reserveWeth, reserveUsdc, 25, bigInt("1001893043187025388")},
+		{"1 WETH for USDC, 30 bps", bigInt("1000000000000000000"), reserveUsdc, reserveWeth, 30, bigInt("1315815638")},
+		{"1 WETH for USDC, 25 bps", bigInt("1000000000000000000"), reserveUsdc, reserveWeth, 25, bigInt("1315156081")},
+		{"dust rounds up", big.NewInt(1), big.NewInt(1000000), big.NewInt(1000000), 30, big.NewInt(2)},
+		{"all but one unit of the reserve", big.NewInt(999), big.NewInt(1000), big.NewInt(1000), 30, big.NewInt(1002007)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GetAmountIn(test.amountOut, test.reserveIn, test.reserveOut, test.feeBps)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Cmp(test.want) != 0 {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetAmountErrors(t *testing.T) {
+	reserve := big.NewInt(1000)
+	tests := []struct {
+		name string
+		call func() (*big.Int, error)
+		want error
+	}{
+		{"zero input", func() (*big.Int, error) { return GetAmountOut(big.NewInt(0), reserve, reserve, 30) }, ErrInsufficientInputAmount},
+		{"empty input reserve", func() (*big.Int, error) { return GetAmountOut(big.NewInt(1), big.NewInt(0), reserve, 30) }, ErrInsufficientLiquidity},
+		{"zero output", func() (*big.Int, error) { return GetAmountIn(big.NewInt(0), reserve, reserve, 30) }, ErrInsufficientOutputAmount},
+		{"empty output reserve", func() (*big.Int, error) { return GetAmountIn(big.NewInt(1), reserve, big.NewInt(0), 30) }, ErrInsufficientLiquidity},
+		// The router reverts on the underflow of reserveOut - amountOut
+		{"output equal to the reserve", func() (*big.Int, error) { return GetAmountIn(big.NewInt(1000), reserve, reserve, 30) }, ErrInsufficientLiquidity},
+		{"output above the reserve", func() (*big.Int, error) { return GetAmountIn(big.NewInt(1001), reserve, reserve, 30) }, ErrInsufficientLiquidity},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := test.call(); !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+// WETH -> USDC -> LINK, optionally with transfer fees on WETH and LINK
+func testHops(wethFeeBps uint, linkFeeBps uint) []*HopReserves {
+	return []*HopReserves{
+		{ReserveIn: reserveWeth, ReserveOut: reserveUsdc, TokenInFeeBps: wethFeeBps},
+		{ReserveIn: reserveUsdcLink, ReserveOut: reserveLink, TokenOutFeeBps: linkFeeBps},
+	}
+}
+
+func TestGetAmountsOut(t *testing.T) {
+	tests := []struct {
+		name string
+		hops []*HopReserves
+		want []*big.Int
+	}{
+		{
+			name: "no transfer fees",
+			hops: testHops(0, 0),
+			want: []*big.Int{bigInt("1000000000000000000"), bigInt("1307867086"), bigInt("198167669282874200")},
+		},
+		{
+			// The first pair receives 95% of the input, the recipient 97% of the last output
+			name: "transfer fees",
+			hops: testHops(500, 300),
+			want: []*big.Int{bigInt("1000000000000000000"), bigInt("1242475285"), bigInt("182611737004667819")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GetAmountsOut(bigInt("1000000000000000000"), test.hops, 30)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertAmounts(t, got, test.want)
+		})
+	}
+}
+
+func TestGetAmountsIn(t *testing.T) {
+	amountOut := bigInt("1000000000000000000000")
+	tests := []struct {
+		name string
+		hops []*HopReserves
+		want []*big.Int
+	}{
+		{
+			name: "no transfer fees",
+			hops: testHops(0, 0),
+			want: []*big.Int{bigInt("5780013758648320838197"), bigInt("6605149627937"), amountOut},
+		},
+		{
+			name: "transfer fees",
+			hops: testHops(500, 300),
+			want: []*big.Int{bigInt("6300738680549161561791"), bigInt("6809603335507"), amountOut},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GetAmountsIn(amountOut, test.hops, 30)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertAmounts(t, got, test.want)
+
+			// Swapping the required input delivers at least the requested output
+			amounts, err := GetAmountsOut(got[0], test.hops, 30)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if received := amounts[len(amounts)-1]; received.Cmp(amountOut) < 0 {
+				t.Errorf("input %v only delivers %v", got[0], received)
+			}
+		})
+	}
+}
+
+func TestGetAmountsInvalidPath(t *testing.T) {
+	if _, err := GetAmountsOut(big.NewInt(1), nil, 30); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("GetAmountsOut: got %v, want %v", err, ErrInvalidPath)
+	}
+
+	if _, err := GetAmountsIn(big.NewInt(1), nil, 30); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("GetAmountsIn: got %v, want %v", err, ErrInvalidPath)
+	}
+}
+
+func TestTransferFeeRounding(t *testing.T) {
+	tests := []struct {
+		amount   *big.Int
+		feeBps   uint
+		applied  *big.Int
+		reversed *big.Int
+	}{
+		{big.NewInt(10000), 0, big.NewInt(10000), big.NewInt(10000)},
+		{big.NewInt(10000), 500, big.NewInt(9500), big.NewInt(10527)},
+		{big.NewInt(19), 100, big.NewInt(18), big.NewInt(20)},
+	}
+
+	for _, test := range tests {
+		if got := applyTransferFee(test.amount, test.feeBps); got.Cmp(test.applied) != 0 {
+			t.Errorf("applyTransferFee(%v, %v) = %v, want %v", test.amount, test.feeBps, got, test.applied)
+		}
+
+		reversed := reverseTransferFee(test.amount, test.feeBps)
+		if reversed.Cmp(test.reversed) != 0 {
+			t.Errorf("reverseTransferFee(%v, %v) = %v, want %v", test.amount, test.feeBps, reversed, test.reversed)
+		}
+		if received := applyTransferFee(reversed, test.feeBps); received.Cmp(test.amount) < 0 {
+			t.Errorf("sending %v only delivers %v after a %v bps fee", reversed, received, test.feeBps)
+		}
+	}
+}
+
+func TestNewQuote(t *testing.T) {
+	hops := []*HopReserves{{ReserveIn: big.NewInt(1000), ReserveOut: big.NewInt(2000)}}
+	amounts, err := GetAmountsOut(big.NewInt(100), hops, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quote := NewQuote(amounts, hops)
+	if quote.AmountOut.Cmp(big.NewInt(181)) != 0 {
+		t.Errorf("amount out %v, want 181", quote.AmountOut)
+	}
+	if quote.MidPrice.Cmp(big.NewRat(2, 1)) != 0 {
+		t.Errorf("mid price %v, want 2", quote.MidPrice)
+	}
+	// 1 - (181/100) / 2
+	if quote.PriceImpact.Cmp(big.NewRat(19, 200)) != 0 || quote.PriceImpactBps() != 950 {
+		t.Errorf("price impact %v (%v bps), want 19/200 (950 bps)", quote.PriceImpact, quote.PriceImpactBps())
+	}
+}
+
+func assertAmounts(t *testing.T, got []*big.Int, want []*big.Int) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v amounts, want %v", len(got), len(want))
+	}
+
+	for i := range want {
+		if got[i].Cmp(want[i]) != 0 {
+			t.Errorf("amounts[%v] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSetReserves(t *testing.T) {
+	token0 := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	token1 := common.HexToAddress("0x0000000000000000000000000000000000000002")
+
+	tests := []struct {
+		name       string
+		tokenIn    common.Address
+		tokenOut   common.Address
+		reserveIn  int64
+		reserveOut int64
+	}{
+		{"token0 to token1", token0, token1, 100, 200},
+		{"token1 to token0", token1, token0, 200, 100},
+	}
+
+	for _, test := range tests {
+		hop := &HopReserves{TokenIn: test.tokenIn, TokenOut: test.tokenOut}
+		hop.setReserves(big.NewInt(100), big.NewInt(200))
+		if hop.ReserveIn.Int64() != test.reserveIn || hop.ReserveOut.Int64() != test.reserveOut {
+			t.Errorf("%v: got in=%v out=%v, want in=%v out=%v",
+				test.name, hop.ReserveIn, hop.ReserveOut, test.reserveIn, test.reserveOut)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package uniswapV3Handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler/uniswapV3Handler/uniswapV3Math"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	defaultTwapWindow          = 5 * time.Minute
+	defaultMaxTwapDeviationBps = 300
+)
+
+var (
+	ErrObservationTooOld = errors.New("pool oracle history is shorter than the window, increase its observation cardinality")
+	ErrPriceDeviation    = errors.New("spot price deviates from the TWAP")
+)
+
+// Time-weighted averages of a pool over a window, as computed by OracleLibrary.consult
+type Twap struct {
+	Pool                  *PoolWrapper
+	Window                time.Duration
+	ArithmeticMeanTick    int
+	HarmonicMeanLiquidity *big.Int
+	Price                 *big.Rat // price of token0 in token1 units at the mean tick
+}
+
+// Length and capacity of the observation history of a pool
+type OracleHistory struct {
+	OldestObservationAge       time.Duration
+	ObservationCardinality     uint16
+	ObservationCardinalityNext uint16
+}
+
+// Returns the time-weighted average tick, price and liquidity of a pool over a window ending now.
+// Returns ErrObservationTooOld if the oracle history of the pool does not cover the window.
+func (h *UniswapV3Handler) FetchTwap(pool *PoolWrapper, window time.Duration) (*Twap, error) {
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.FetchTwapAt(snapshot, pool, window)
+}
+
+// Returns the time-weighted averages of a pool over a window ending at the block of a snapshot
+func (h *UniswapV3Handler) FetchTwapAt(snapshot *ethHandler.ChainSnapshot, pool *PoolWrapper, window time.Duration) (*Twap, error) {
+	windowSeconds := uint32(window / time.Second)
+	if windowSeconds == 0 {
+		return nil, errors.New("TWAP window must be at least one second")
+	}
+
+	instance, err := h.getPoolInstance(pool.PoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	observations, err := instance.Observe(snapshot.CallOpts(), []uint32{windowSeconds, 0})
+	if err != nil {
+		// The pool reverts with OLD when the oldest observation is more recent than the window
+		if strings.Contains(err.Error(), "OLD") {
+			return nil, fmt.Errorf("%w: %v over %v", ErrObservationTooOld, pool, window)
+		}
+		return nil, fmt.Errorf("unable to observe pool %v: %w", pool, err)
+	}
+
+	// Arithmetic mean tick, rounded to negative infinity
+	tickCumulativesDelta := new(big.Int).Sub(observations.TickCumulatives[1], observations.TickCumulatives[0])
+	meanTick, remainder := new(big.Int).QuoRem(tickCumulativesDelta, big.NewInt(int64(windowSeconds)), new(big.Int))
+	if tickCumulativesDelta.Sign() < 0 && remainder.Sign() != 0 {
+		meanTick.Sub(meanTick, big.NewInt(1))
+	}
+
+	// The seconds per liquidity accumulator is a uint160 which may wrap around
+	secondsPerLiquidityDelta := new(big.Int).Sub(
+		observations.SecondsPerLiquidityCumulativeX128s[1],
+		observations.SecondsPerLiquidityCumulativeX128s[0])
+	secondsPerLiquidityDelta.And(secondsPerLiquidityDelta, uniswapV3Math.MaxUint160)
+	if secondsPerLiquidityDelta.Sign() == 0 {
+		return nil, fmt.Errorf("no liquidity in pool %v over %v", pool, window)
+	}
+
+	// window * (2^160 - 1) / (secondsPerLiquidityDelta << 32)
+	harmonicMeanLiquidity := new(big.Int).Mul(big.NewInt(int64(windowSeconds)), uniswapV3Math.MaxUint160)
+	harmonicMeanLiquidity.Quo(harmonicMeanLiquidity, secondsPerLiquidityDelta.Lsh(secondsPerLiquidityDelta, 32))
+
+	sqrtPriceX96, err := uniswapV3Math.GetSqrtRatioAtTick(int(meanTick.Int64()))
+	if err != nil {
+		return nil, err
+	}
+
+	token0, err := ethHandler.GetToken(pool.ChainId, pool.Token0Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	token1, err := ethHandler.GetToken(pool.ChainId, pool.Token1Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	twap := &Twap{
+		Pool:                  pool,
+		Window:                window,
+		ArithmeticMeanTick:    int(meanTick.Int64()),
+		HarmonicMeanLiquidity: harmonicMeanLiquidity,
+		Price:                 sqrtPriceToTokenPrice(sqrtPriceX96, token0, token1),
+	}
+
+	return twap, nil
+}
+
+// Returns the time-weighted average price of the pool with the most in-range liquidity
+// among the fee tiers of the pair
+func (h *UniswapV3Handler) FetchTwapTickerInfo(base string, quote string, window time.Duration) (models.TickerInfo, error) {
+	var result models.TickerInfo
+	baseToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, base)
+	if err != nil {
+		return result, err
+	}
+
+	quoteToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, quote)
+	if err != nil {
+		return result, err
+	}
+
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		return result, err
+	}
+
+	deepest, err := h.FindDeepestPoolAt(snapshot, baseToken, quoteToken)
+	if err != nil {
+		return result, err
+	}
+
+	twap, err := h.FetchTwapAt(snapshot, deepest.Pool, window)
+	if err != nil {
+		return result, err
+	}
+
+	token0, err := ethHandler.GetToken(twap.Pool.ChainId, twap.Pool.Token0Symbol)
+	if err != nil {
+		return result, err
+	}
+
+	token1, err := ethHandler.GetToken(twap.Pool.ChainId, twap.Pool.Token1Symbol)
+	if err != nil {
+		return result, err
+	}
+
+	result = models.TickerInfo{
+		Symbol:         twap.Pool.Symbol(),
+		Base:           string(token0.AssetId()),
+		Quote:          string(token1.AssetId()),
+		Price:          twap.Price.FloatString(int(token1.Decimals)),
+		MakerComission: twap.Pool.FeeString(),
+		TakerComission: twap.Pool.FeeString(),
+		Timestamp:      time.Now(),
+		BlockNumber:    snapshot.Number(),
+	}
+
+	return result, nil
+}
+
+// Returns the age of the oldest observation of a pool, which bounds the longest TWAP window
+func (h *UniswapV3Handler) FetchOracleHistory(pool *PoolWrapper) (*OracleHistory, error) {
+	instance, err := h.getPoolInstance(pool.PoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := h.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	callOpts := &bind.CallOpts{BlockNumber: header.Number}
+	poolState, err := instance.Slot0(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch slot0 of pool %v: %w", pool, err)
+	}
+
+	if poolState.ObservationCardinality == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrPoolNotInitialized, pool)
+	}
+
+	// The observations are a ring buffer, the oldest one follows the latest one
+	// unless the buffer has not been filled yet
+	oldestIndex := (uint32(poolState.ObservationIndex) + 1) % uint32(poolState.ObservationCardinality)
+	oldest, err := instance.Observations(callOpts, big.NewInt(int64(oldestIndex)))
+	if err != nil {
+		return nil, err
+	}
+
+	if !oldest.Initialized {
+		oldest, err = instance.Observations(callOpts, big.NewInt(0))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	history := &OracleHistory{
+		OldestObservationAge:       time.Duration(header.Time-uint64(oldest.BlockTimestamp)) * time.Second,
+		ObservationCardinality:     poolState.ObservationCardinality,
+		ObservationCardinalityNext: poolState.ObservationCardinalityNext,
+	}
+
+	return history, nil
+}
+
+// Returns the number of observations needed to cover a window when a pool is traded every block
+func RequiredObservationCardinality(window time.Duration, blockTime time.Duration) uint16 {
+	cardinality := uint64(window/blockTime) + 1
+	if cardinality > math.MaxUint16 {
+		return math.MaxUint16
+	}
+
+	return uint16(cardinality)
+}
+
+// Raises the observation capacity of a pool so that its oracle history grows long enough for a TWAP window.
+// The new observations are initialized by the transaction, its sender pays the gas of the extra storage.
+// Returns a nil transaction if the capacity is already large enough.
+func (h *UniswapV3Handler) IncreaseObservationCardinalityNext(
+	wallet *ethHandler.Wallet,
+	pool *PoolWrapper,
+	cardinality uint16,
+) (*types.Transaction, error) {
+	history, err := h.FetchOracleHistory(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	if history.ObservationCardinalityNext >= cardinality {
+		return nil, nil
+	}
+
+	instance, err := h.getPoolInstance(pool.PoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId := big.NewInt(int64(h.Network.ChainId))
+	auth, err := bind.NewKeyedTransactorWithChainID(wallet.PrivateKey, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := instance.IncreaseObservationCardinalityNext(auth, cardinality)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = h.WaitTxMined(tx, wallet.Address, txMineWaitTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// Returns ErrPriceDeviation if the spot price of a pool deviates from its TWAP by more than maxDeviationBps.
+// The spot price and the TWAP are read at the same block.
+func (h *UniswapV3Handler) CheckSpotPrice(pool *PoolWrapper, window time.Duration, maxDeviationBps uint) error {
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		return err
+	}
+
+	twap, err := h.FetchTwapAt(snapshot, pool, window)
+	if err != nil {
+		return err
+	}
+
+	instance, err := h.getPoolInstance(pool.PoolAddress)
+	if err != nil {
+		return err
+	}
+
+	poolState, err := instance.Slot0(snapshot.CallOpts())
+	if err != nil {
+		return fmt.Errorf("unable to fetch slot0 of pool %v: %w", pool, err)
+	}
+
+	twapSqrtPriceX96, err := uniswapV3Math.GetSqrtRatioAtTick(twap.ArithmeticMeanTick)
+	if err != nil {
+		return err
+	}
+
+	// |spot / twap - 1| in basis points, from the squared sqrt prices
+	ratio := new(big.Rat).SetFrac(
+		new(big.Int).Mul(poolState.SqrtPriceX96, poolState.SqrtPriceX96),
+		new(big.Int).Mul(twapSqrtPriceX96, twapSqrtPriceX96))
+	deviation := ratio.Sub(ratio, big.NewRat(1, 1))
+	deviation.Abs(deviation)
+	deviation.Mul(deviation, big.NewRat(10000, 1))
+
+	if deviation.Cmp(new(big.Rat).SetInt64(int64(maxDeviationBps))) > 0 {
+		return fmt.Errorf("%w: %v deviates %v bps from its %v TWAP", ErrPriceDeviation, pool, deviation.FloatString(0), window)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package uniswapV3Handler
+
+import (
+	"math/big"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+)
+
+// Builds a swap order from the current state of the pools of every fee tier.
+// An exact input buy spends the quote amount currently required to receive the base quantity
+// and an exact output buy receives exactly the base quantity. A sell spends the base quantity.
+// The minimum output or maximum input is bounded by the slippage.
+func (h *UniswapV3Handler) BuildOrder(params *ethHandler.OrderParams) (models.Order, error) {
+	var order models.Order
+	if err := params.Validate(); err != nil {
+		return order, err
+	}
+
+	baseToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, params.Base)
+	if err != nil {
+		return order, err
+	}
+
+	quoteToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, params.Quote)
+	if err != nil {
+		return order, err
+	}
+
+	quantity, err := baseToken.ParseAmount(params.Quantity)
+	if err != nil {
+		return order, err
+	}
+
+	var amountIn, amountOut *big.Int
+	var price *big.Rat
+	if params.Action == models.BuyLongSpot {
+		route, err := h.FindBestPools(quoteToken, baseToken, quantity, models.ExactOutput)
+		if err != nil {
+			return order, err
+		}
+
+		amountIn = route.AmountIn
+		amountOut = quantity
+		price = ethHandler.ExecutionPrice(baseToken, quantity, quoteToken, amountIn)
+	} else {
+		route, err := h.FindBestPools(baseToken, quoteToken, quantity, models.ExactInput)
+		if err != nil {
+			return order, err
+		}
+
+		amountIn = quantity
+		amountOut = route.AmountOut
+		price = ethHandler.ExecutionPrice(baseToken, quantity, quoteToken, amountOut)
+	}
+
+	order = models.Order{
+		Exchange:         h.ExchangeInfo,
+		Base:             params.Base,
+		Quote:            params.Quote,
+		Action:           params.Action,
+		Price:            price,
+		Quantity:         quantity,
+		SwapKind:         params.SwapKind,
+		LiqPoolAmountIn:  amountIn,
+		LiqPoolAmountOut: ethHandler.AmountOutMin(amountOut, params.SlippageBps),
+		Deadline:         params.Deadline,
+	}
+
+	if params.SwapKind == models.ExactOutput {
+		order.LiqPoolAmountIn = ethHandler.AmountInMax(amountIn, params.SlippageBps)
+		order.LiqPoolAmountOut = amountOut
+	}
+
+	return order, nil
+}
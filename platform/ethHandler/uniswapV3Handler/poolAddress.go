@@ -0,0 +1,55 @@
+package uniswapV3Handler
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// keccak256 of the UniswapV3Pool creation code
+var poolInitCodeHash = common.HexToHash("0xe34f199b19b2b4f47f68442619d555527d244f78a3297ea89325f843f87b8b54")
+
+// TODO: Parse from json or config file
+var factoryAddressMap = map[ethHandler.ChainId]string{
+	1:     "0x1F98431c8aD98523631AE4a59f267346ea31F984",
+	5:     "0x1F98431c8aD98523631AE4a59f267346ea31F984",
+	10:    "0x1F98431c8aD98523631AE4a59f267346ea31F984",
+	137:   "0x1F98431c8aD98523631AE4a59f267346ea31F984",
+	80001: "0x1F98431c8aD98523631AE4a59f267346ea31F984",
+	42161: "0x1F98431c8aD98523631AE4a59f267346ea31F984",
+	42220: "0xAfE208a311B21f13EF87E33A90049fC17A7acDEc",
+}
+
+func GetFactoryAddress(chainId ethHandler.ChainId) (common.Address, error) {
+	address, found := factoryAddressMap[chainId]
+	if !found {
+		return common.Address{}, fmt.Errorf("no uniswap v3 factory on chainId=%v", chainId)
+	}
+
+	return common.HexToAddress(address), nil
+}
+
+// Computes the address of the pool of two tokens and a fee tier without querying the factory.
+// Pools are deployed with CREATE2 using keccak256(abi.encode(token0, token1, fee)) as the salt:
+// address = keccak256(0xff ++ factory ++ salt ++ poolInitCodeHash)[12:]
+// Note: the pool is not guaranteed to be deployed at the returned address.
+func ComputePoolAddress(
+	factoryAddress common.Address,
+	tokenA common.Address,
+	tokenB common.Address,
+	fee uint,
+) common.Address {
+	token0, token1 := ethHandler.SortTokens(tokenA, tokenB)
+
+	// abi.encode pads every argument to 32 bytes
+	salt := crypto.Keccak256Hash(
+		common.LeftPadBytes(token0.Bytes(), 32),
+		common.LeftPadBytes(token1.Bytes(), 32),
+		common.LeftPadBytes(new(big.Int).SetUint64(uint64(fee)).Bytes(), 32),
+	)
+
+	return crypto.CreateAddress2(factoryAddress, salt, poolInitCodeHash.Bytes())
+}
@@ -0,0 +1,40 @@
+package uniswapV3Handler
+
+import (
+	"testing"
+
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Every pool of the registry must be found at its CREATE2 address
+func TestComputePoolAddress(t *testing.T) {
+	for key, pool := range poolsMap {
+		t.Run(key, func(t *testing.T) {
+			factoryAddress, err := GetFactoryAddress(pool.ChainId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token0, err := ethHandler.GetToken(pool.ChainId, pool.Token0Symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token1, err := ethHandler.GetToken(pool.ChainId, pool.Token1Symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := common.HexToAddress(pool.PoolAddress)
+			if got := ComputePoolAddress(factoryAddress, token0.AddressForGeth(), token1.AddressForGeth(), pool.Fee); got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+
+			// The address does not depend on the order of the tokens
+			if got := ComputePoolAddress(factoryAddress, token1.AddressForGeth(), token0.AddressForGeth(), pool.Fee); got != want {
+				t.Errorf("reversed tokens: got %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package uniswapV3Handler
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	defaultMaxPoolSplits = 2  // every additional pool costs the gas of another swap
+	poolSplitSteps       = 20 // granularity of the split of an order across pools (5%)
+)
+
+// Fee tiers enabled on the Uniswap V3 factory, in hundredths of a basis point
+var FeeTiers = []uint{100, 500, 3000, 10000}
+
+var (
+	ErrNoPool                = errors.New("no pool with liquidity")
+	ErrInsufficientLiquidity = errors.New("insufficient liquidity")
+)
+
+// Amounts swapped on one pool of a route
+type PoolAllocation struct {
+	Pool      *PoolSnapshot
+	AmountIn  *big.Int
+	AmountOut *big.Int
+}
+
+// Swap of two tokens split across the pools of one or more fee tiers
+type PoolRoute struct {
+	TokenIn     common.Address
+	TokenOut    common.Address
+	SwapKind    models.SwapKind
+	Allocations []*PoolAllocation
+	AmountIn    *big.Int
+	AmountOut   *big.Int
+}
+
+// Loads a snapshot of the pools of every fee tier of two tokens at the latest block.
+// Tiers without a deployed and initialized pool are skipped.
+func (h *UniswapV3Handler) FetchPoolSnapshots(tokenA *ethHandler.Token, tokenB *ethHandler.Token) ([]*PoolSnapshot, error) {
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.FetchPoolSnapshotsAt(snapshot, tokenA, tokenB)
+}
+
+// Loads a snapshot of the pools of every fee tier of two tokens at the block of a chain snapshot
+func (h *UniswapV3Handler) FetchPoolSnapshotsAt(
+	snapshot *ethHandler.ChainSnapshot,
+	tokenA *ethHandler.Token,
+	tokenB *ethHandler.Token,
+) ([]*PoolSnapshot, error) {
+	pools := make([]*PoolWrapper, len(FeeTiers))
+	for i, fee := range FeeTiers {
+		var err error
+		pools[i], err = GetPoolForTokens(h.Network.ChainId, fee, tokenA, tokenB)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	poolSnapshots, err := h.LoadPoolSnapshotsAt(pools, snapshot.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*PoolSnapshot
+	for _, poolSnapshot := range poolSnapshots {
+		if poolSnapshot != nil {
+			result = append(result, poolSnapshot)
+		}
+	}
+
+	return result, nil
+}
+
+// Returns the pool with the most in-range liquidity among the fee tiers of two tokens
+func (h *UniswapV3Handler) FindDeepestPool(tokenA *ethHandler.Token, tokenB *ethHandler.Token) (*PoolSnapshot, error) {
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.FindDeepestPoolAt(snapshot, tokenA, tokenB)
+}
+
+// Returns the pool with the most in-range liquidity among the fee tiers of two tokens
+// at the block of a chain snapshot
+func (h *UniswapV3Handler) FindDeepestPoolAt(
+	snapshot *ethHandler.ChainSnapshot,
+	tokenA *ethHandler.Token,
+	tokenB *ethHandler.Token,
+) (*PoolSnapshot, error) {
+	poolSnapshots, err := h.FetchPoolSnapshotsAt(snapshot, tokenA, tokenB)
+	if err != nil {
+		return nil, err
+	}
+
+	var deepest *PoolSnapshot
+	for _, poolSnapshot := range poolSnapshots {
+		if deepest == nil || poolSnapshot.Liquidity.Cmp(deepest.Liquidity) > 0 {
+			deepest = poolSnapshot
+		}
+	}
+
+	if deepest == nil {
+		return nil, fmt.Errorf("%w for %v/%v", ErrNoPool, tokenA.Symbol, tokenB.Symbol)
+	}
+
+	return deepest, nil
+}
+
+// Simulates a swap and returns the input of an exact output swap or the output of an exact input swap
+func (s *PoolSnapshot) estimate(tokenIn common.Address, amount *big.Int, swapKind models.SwapKind) (*big.Int, error) {
+	if amount.Sign() == 0 {
+		return new(big.Int), nil
+	}
+
+	simulated, err := s.Simulate(tokenIn, amount, swapKind, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !simulated.Filled {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	if swapKind == models.ExactOutput {
+		return simulated.AmountIn, nil
+	}
+
+	return simulated.AmountOut, nil
+}
+
+// Searches the pools of every fee tier for the best execution of a swap.
+// Swaps are simulated across the initialized ticks of every pool and the amount is
+// allocated in steps to the pool with the best marginal output (or the lowest marginal
+// input of an exact output swap), which splits large orders across fee tiers.
+// At most MaxPoolSplits pools are used.
+func (h *UniswapV3Handler) FindBestPools(
+	tokenIn *ethHandler.Token,
+	tokenOut *ethHandler.Token,
+	amount *big.Int,
+	swapKind models.SwapKind,
+) (*PoolRoute, error) {
+	if amount.Sign() <= 0 {
+		return nil, errors.New("swap amount must be positive")
+	}
+
+	snapshots, err := h.FetchPoolSnapshots(tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("%w for %v/%v", ErrNoPool, tokenIn.Symbol, tokenOut.Symbol)
+	}
+
+	maxSplits := h.MaxPoolSplits
+	if maxSplits <= 0 {
+		maxSplits = defaultMaxPoolSplits
+	}
+
+	route, err := splitAcrossPools(snapshots, tokenIn.AddressForGeth(), tokenOut.AddressForGeth(), amount, swapKind, maxSplits)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %v %v/%v", err, amount, tokenIn.Symbol, tokenOut.Symbol)
+	}
+
+	return route, nil
+}
+
+func splitAcrossPools(
+	snapshots []*PoolSnapshot,
+	tokenIn common.Address,
+	tokenOut common.Address,
+	amount *big.Int,
+	swapKind models.SwapKind,
+	maxSplits int,
+) (*PoolRoute, error) {
+	steps := poolSplitSteps
+	if maxSplits == 1 {
+		steps = 1
+	}
+
+	// The amount allocated to every pool and its estimated counterpart
+	allocated := make([]*big.Int, len(snapshots))
+	estimates := make([]*big.Int, len(snapshots))
+	for i := range snapshots {
+		allocated[i] = new(big.Int)
+		estimates[i] = new(big.Int)
+	}
+	usedPools := 0
+
+	stepAmount, remainder := new(big.Int).QuoRem(amount, big.NewInt(int64(steps)), new(big.Int))
+	for step := 0; step < steps; step++ {
+		increment := new(big.Int).Set(stepAmount)
+		if step == 0 {
+			increment.Add(increment, remainder)
+		}
+		if increment.Sign() == 0 {
+			continue
+		}
+
+		bestIndex := -1
+		var bestEstimate, bestMarginal *big.Int
+		for i, snapshot := range snapshots {
+			if usedPools >= maxSplits && allocated[i].Sign() == 0 {
+				continue
+			}
+
+			estimate, err := snapshot.estimate(tokenIn, new(big.Int).Add(allocated[i], increment), swapKind)
+			if errors.Is(err, ErrInsufficientLiquidity) || errors.Is(err, ErrTickDataLimit) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			marginal := new(big.Int).Sub(estimate, estimates[i])
+			better := bestMarginal == nil || marginal.Cmp(bestMarginal) > 0
+			if swapKind == models.ExactOutput {
+				better = bestMarginal == nil || marginal.Cmp(bestMarginal) < 0
+			}
+
+			if better {
+				bestIndex, bestEstimate, bestMarginal = i, estimate, marginal
+			}
+		}
+
+		if bestIndex < 0 {
+			return nil, ErrInsufficientLiquidity
+		}
+
+		if allocated[bestIndex].Sign() == 0 {
+			usedPools++
+		}
+		allocated[bestIndex].Add(allocated[bestIndex], increment)
+		estimates[bestIndex] = bestEstimate
+	}
+
+	route := &PoolRoute{
+		TokenIn:   tokenIn,
+		TokenOut:  tokenOut,
+		SwapKind:  swapKind,
+		AmountIn:  new(big.Int),
+		AmountOut: new(big.Int),
+	}
+
+	for i, snapshot := range snapshots {
+		if allocated[i].Sign() == 0 {
+			continue
+		}
+
+		allocation := &PoolAllocation{Pool: snapshot, AmountIn: allocated[i], AmountOut: estimates[i]}
+		if swapKind == models.ExactOutput {
+			allocation.AmountIn, allocation.AmountOut = estimates[i], allocated[i]
+		}
+
+		route.Allocations = append(route.Allocations, allocation)
+		route.AmountIn.Add(route.AmountIn, allocation.AmountIn)
+		route.AmountOut.Add(route.AmountOut, allocation.AmountOut)
+	}
+
+	return route, nil
+}
+
+// Returns the fee tiers used by the route
+func (r *PoolRoute) Fees() []uint {
+	fees := make([]uint, len(r.Allocations))
+	for i, allocation := range r.Allocations {
+		fees[i] = allocation.Pool.Pool.Fee
+	}
+
+	return fees
+}
+
+// Splits the swap limit of an order across the pools of the route, in proportion to
+// the amounts estimated for each pool. The minimum output of an exact input swap is
+// split by output and the maximum input of an exact output swap by input.
+func (r *PoolRoute) SwapParams(amountLimit *big.Int) []*SwapParams {
+	total := r.AmountOut
+	if r.SwapKind == models.ExactOutput {
+		total = r.AmountIn
+	}
+
+	result := make([]*SwapParams, len(r.Allocations))
+	for i, allocation := range r.Allocations {
+		params := &SwapParams{
+			Tokens:   []common.Address{r.TokenIn, r.TokenOut},
+			Fees:     []uint{allocation.Pool.Pool.Fee},
+			SwapKind: r.SwapKind,
+		}
+
+		params.Amount, params.AmountLimit = allocation.AmountIn, new(big.Int).Set(amountLimit)
+		share := allocation.AmountOut
+		if r.SwapKind == models.ExactOutput {
+			params.Amount = allocation.AmountOut
+			share = allocation.AmountIn
+		}
+
+		if len(r.Allocations) > 1 && total.Sign() > 0 {
+			params.AmountLimit.Mul(params.AmountLimit, share)
+			params.AmountLimit.Quo(params.AmountLimit, total)
+		}
+
+		result[i] = params
+	}
+
+	return result
+}
+
+func (r *PoolRoute) String() string {
+	total := r.AmountIn
+	if r.SwapKind == models.ExactOutput {
+		total = r.AmountOut
+	}
+
+	var pools []string
+	for _, allocation := range r.Allocations {
+		amount := allocation.AmountIn
+		if r.SwapKind == models.ExactOutput {
+			amount = allocation.AmountOut
+		}
+
+		share := new(big.Rat).SetFrac(new(big.Int).Mul(amount, big.NewInt(100)), total)
+		pools = append(pools, fmt.Sprintf("fee=%v%% share=%v%%", allocation.Pool.Pool.FeeString(), share.FloatString(1)))
+	}
+
+	return fmt.Sprintf("%v(in=%v out=%v) [%v]", r.SwapKind, r.AmountIn, r.AmountOut, strings.Join(pools, ", "))
+}
@@ -0,0 +1,180 @@
+package uniswapV3Handler
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler/uniswapV3Handler/uniswapV3Math"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	testToken0 = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	testToken1 = common.HexToAddress("0x0000000000000000000000000000000000000002")
+)
+
+// Returns a snapshot of a pool at price 1 whose liquidity is the same at every tick.
+// The whole tick bitmap is loaded with no initialized tick, so swaps never reach the chain.
+func newTestPoolSnapshot(fee uint, liquidity string) *PoolSnapshot {
+	liquidityAmount, _ := new(big.Int).SetString(liquidity, 10)
+	snapshot := &PoolSnapshot{
+		Pool:          &PoolWrapper{Fee: fee},
+		Token0:        testToken0,
+		Token1:        testToken1,
+		SqrtPriceX96:  new(big.Int).Lsh(big.NewInt(1), 96),
+		Tick:          0,
+		Liquidity:     liquidityAmount,
+		TickSpacing:   60,
+		words:         make(map[int16]*big.Int),
+		liquidityNets: make(map[int]*big.Int),
+	}
+	maxWordPosition := int16(uniswapV3Math.MaxTick/snapshot.TickSpacing>>8 + 1)
+	for wordPosition := -maxWordPosition; wordPosition <= maxWordPosition; wordPosition++ {
+		snapshot.words[wordPosition] = new(big.Int)
+	}
+
+	return snapshot
+}
+
+func testAmount(amount string) *big.Int {
+	result, _ := new(big.Int).SetString(amount, 10)
+	return result
+}
+
+func TestSplitAcrossPools(t *testing.T) {
+	tests := []struct {
+		name      string
+		pools     []*PoolSnapshot
+		amount    *big.Int
+		swapKind  models.SwapKind
+		maxSplits int
+		want      []*big.Int // amount allocated to each used pool, in the order of the pools
+	}{
+		{
+			name:      "single deep pool takes the whole amount",
+			pools:     []*PoolSnapshot{newTestPoolSnapshot(3000, "1000000000000"), newTestPoolSnapshot(3000, "1000000000000000000000000")},
+			amount:    testAmount("1000000000000000"),
+			swapKind:  models.ExactInput,
+			maxSplits: 2,
+			want:      []*big.Int{testAmount("1000000000000000")},
+		},
+		{
+			name:      "equal pools split the amount",
+			pools:     []*PoolSnapshot{newTestPoolSnapshot(3000, "1000000000000000000"), newTestPoolSnapshot(3000, "1000000000000000000")},
+			amount:    testAmount("100000000000000000"),
+			swapKind:  models.ExactInput,
+			maxSplits: 2,
+			want:      []*big.Int{testAmount("50000000000000000"), testAmount("50000000000000000")},
+		},
+		{
+			name:      "equal pools split the output of an exact output swap",
+			pools:     []*PoolSnapshot{newTestPoolSnapshot(3000, "1000000000000000000"), newTestPoolSnapshot(3000, "1000000000000000000")},
+			amount:    testAmount("100000000000000000"),
+			swapKind:  models.ExactOutput,
+			maxSplits: 2,
+			want:      []*big.Int{testAmount("50000000000000000"), testAmount("50000000000000000")},
+		},
+		{
+			name:      "single pool allowed",
+			pools:     []*PoolSnapshot{newTestPoolSnapshot(3000, "1000000000000000000"), newTestPoolSnapshot(3000, "1000000000000000000")},
+			amount:    testAmount("100000000000000000"),
+			swapKind:  models.ExactInput,
+			maxSplits: 1,
+			want:      []*big.Int{testAmount("100000000000000000")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			route, err := splitAcrossPools(test.pools, testToken0, testToken1, test.amount, test.swapKind, test.maxSplits)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(route.Allocations) != len(test.want) {
+				t.Fatalf("got %v pools, want %v", len(route.Allocations), len(test.want))
+			}
+
+			for i, allocation := range route.Allocations {
+				allocated, estimated := allocation.AmountIn, allocation.AmountOut
+				if test.swapKind == models.ExactOutput {
+					allocated, estimated = allocation.AmountOut, allocation.AmountIn
+				}
+
+				if allocated.Cmp(test.want[i]) != 0 {
+					t.Errorf("pool %v: got %v, want %v", i, allocated, test.want[i])
+				}
+
+				// The fee is paid on the input
+				if test.swapKind == models.ExactOutput && estimated.Cmp(allocated) <= 0 {
+					t.Errorf("pool %v: input %v not above output %v", i, estimated, allocated)
+				}
+				if test.swapKind == models.ExactInput && estimated.Cmp(allocated) >= 0 {
+					t.Errorf("pool %v: output %v not below input %v", i, estimated, allocated)
+				}
+			}
+
+			total := route.AmountIn
+			if test.swapKind == models.ExactOutput {
+				total = route.AmountOut
+			}
+			if total.Cmp(test.amount) != 0 {
+				t.Errorf("got total %v, want %v", total, test.amount)
+			}
+		})
+	}
+}
+
+// The swap limits of the pools never add up to more than the limit of the order
+func TestPoolRouteSwapParams(t *testing.T) {
+	for _, swapKind := range []models.SwapKind{models.ExactInput, models.ExactOutput} {
+		t.Run(swapKind.String(), func(t *testing.T) {
+			pools := []*PoolSnapshot{newTestPoolSnapshot(500, "3000000000000000000"), newTestPoolSnapshot(3000, "1000000000000000000")}
+			route, err := splitAcrossPools(pools, testToken0, testToken1, testAmount("100000000000000001"), swapKind, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(route.Allocations) != 2 {
+				t.Fatalf("got %v pools, want 2", len(route.Allocations))
+			}
+
+			// 1% slippage on the estimated counterpart
+			limit := new(big.Int).Mul(route.AmountOut, big.NewInt(99))
+			if swapKind == models.ExactOutput {
+				limit = new(big.Int).Mul(route.AmountIn, big.NewInt(101))
+			}
+			limit.Quo(limit, big.NewInt(100))
+
+			params := route.SwapParams(limit)
+			if len(params) != len(route.Allocations) {
+				t.Fatalf("got %v swaps, want %v", len(params), len(route.Allocations))
+			}
+
+			limitSum := new(big.Int)
+			for i, swap := range params {
+				allocation := route.Allocations[i]
+				want := allocation.AmountIn
+				if swapKind == models.ExactOutput {
+					want = allocation.AmountOut
+				}
+				if swap.Amount.Cmp(want) != 0 {
+					t.Errorf("swap %v: got amount %v, want %v", i, swap.Amount, want)
+				}
+				if swap.Fees[0] != allocation.Pool.Pool.Fee {
+					t.Errorf("swap %v: got fee %v, want %v", i, swap.Fees[0], allocation.Pool.Pool.Fee)
+				}
+
+				limitSum.Add(limitSum, swap.AmountLimit)
+			}
+
+			if limitSum.Cmp(limit) > 0 {
+				t.Errorf("limits add up to %v, above %v", limitSum, limit)
+			}
+			// Rounding down loses less than one unit per pool
+			if new(big.Int).Sub(limit, limitSum).Cmp(big.NewInt(int64(len(params)))) >= 0 {
+				t.Errorf("limits add up to %v, want about %v", limitSum, limit)
+			}
+		})
+	}
+}
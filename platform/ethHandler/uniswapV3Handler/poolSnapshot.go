@@ -0,0 +1,319 @@
+package uniswapV3Handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV3Pool"
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler/uniswapV3Handler/uniswapV3Math"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Swaps moving the price through empty ranges would otherwise load every word up to the price bounds
+const maxBitmapWordLoads = 64
+
+var (
+	ErrPoolNotInitialized = errors.New("pool is not initialized")
+	ErrTickDataLimit      = errors.New("tick bitmap word load limit reached")
+)
+
+// State of a pool read at a block. The tick bitmap words and the ticks are loaded
+// on demand as simulated swaps reach them, at the block of the snapshot.
+type PoolSnapshot struct {
+	Pool         *PoolWrapper
+	Token0       common.Address
+	Token1       common.Address
+	BlockNumber  *big.Int
+	SqrtPriceX96 *big.Int
+	Tick         int
+	Liquidity    *big.Int
+	TickSpacing  int
+
+	instance      *uniswapV3Pool.UniswapV3Pool
+	mu            sync.Mutex
+	words         map[int16]*big.Int
+	liquidityNets map[int]*big.Int
+}
+
+// Result of a simulated swap, oriented in the direction of the swap
+type SimulatedSwap struct {
+	TokenIn      common.Address
+	AmountIn     *big.Int
+	AmountOut    *big.Int
+	FeeAmount    *big.Int
+	SqrtPriceX96 *big.Int // price after the swap
+	Tick         int      // tick after the swap
+	TicksCrossed []int
+	Filled       bool // false if the price limit was reached before the full amount was swapped
+}
+
+// Reads the state of a pool at the latest block.
+// Returns bind.ErrNoCode if the pool is not deployed.
+func (h *UniswapV3Handler) LoadPoolSnapshot(pool *PoolWrapper) (*PoolSnapshot, error) {
+	blockNumber, err := h.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return h.LoadPoolSnapshotAt(pool, new(big.Int).SetUint64(blockNumber))
+}
+
+// Reads the state of a pool at a block
+func (h *UniswapV3Handler) LoadPoolSnapshotAt(pool *PoolWrapper, blockNumber *big.Int) (*PoolSnapshot, error) {
+	instance, err := h.getPoolInstance(pool.PoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	callOpts := &bind.CallOpts{BlockNumber: blockNumber}
+	liquidity, err := instance.Liquidity(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch liquidity of pool %v: %w", pool, err)
+	}
+
+	poolState, err := instance.Slot0(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch slot0 of pool %v: %w", pool, err)
+	}
+
+	if poolState.SqrtPriceX96.Sign() == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrPoolNotInitialized, pool)
+	}
+
+	tickSpacing, err := instance.TickSpacing(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch tick spacing of pool %v: %w", pool, err)
+	}
+
+	return h.newPoolSnapshot(pool, blockNumber, poolState.SqrtPriceX96, poolState.Tick, liquidity, tickSpacing)
+}
+
+// Reads the state of pools in one multicall at a block.
+// The snapshot of a pool that is not deployed or not initialized is nil.
+func (h *UniswapV3Handler) LoadPoolSnapshotsAt(pools []*PoolWrapper, blockNumber *big.Int) ([]*PoolSnapshot, error) {
+	poolAbi, err := uniswapV3Pool.UniswapV3PoolMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	multicaller, err := h.NewMulticaller()
+	if err != nil {
+		return nil, err
+	}
+
+	// slot0, liquidity and tickSpacing of every pool
+	var calls []*ethHandler.MulticallCall
+	for _, pool := range pools {
+		poolAddress := common.HexToAddress(pool.PoolAddress)
+		for _, method := range []string{"slot0", "liquidity", "tickSpacing"} {
+			call := ethHandler.NewMulticallCall(poolAddress, poolAbi, method)
+			call.AllowFailure = true
+			calls = append(calls, call)
+		}
+	}
+
+	results, err := multicaller.Call(&bind.CallOpts{BlockNumber: blockNumber}, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*PoolSnapshot, len(pools))
+	for i, pool := range pools {
+		slot0, liquidity, tickSpacing := results[3*i], results[3*i+1], results[3*i+2]
+		if errors.Is(slot0.Err, bind.ErrNoCode) {
+			continue
+		}
+
+		for _, result := range []*ethHandler.MulticallResult{slot0, liquidity, tickSpacing} {
+			if result.Err != nil {
+				return nil, fmt.Errorf("unable to fetch state of pool %v: %w", pool, result.Err)
+			}
+		}
+
+		sqrtPriceX96 := slot0.Outputs[0].(*big.Int)
+		if sqrtPriceX96.Sign() == 0 {
+			continue
+		}
+
+		snapshots[i], err = h.newPoolSnapshot(
+			pool,
+			blockNumber,
+			sqrtPriceX96,
+			slot0.Outputs[1].(*big.Int),
+			liquidity.Outputs[0].(*big.Int),
+			tickSpacing.Outputs[0].(*big.Int))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshots, nil
+}
+
+func (h *UniswapV3Handler) newPoolSnapshot(
+	pool *PoolWrapper,
+	blockNumber *big.Int,
+	sqrtPriceX96 *big.Int,
+	tick *big.Int,
+	liquidity *big.Int,
+	tickSpacing *big.Int,
+) (*PoolSnapshot, error) {
+	instance, err := h.getPoolInstance(pool.PoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	token0, err := ethHandler.GetToken(pool.ChainId, pool.Token0Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	token1, err := ethHandler.GetToken(pool.ChainId, pool.Token1Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &PoolSnapshot{
+		Pool:          pool,
+		Token0:        token0.AddressForGeth(),
+		Token1:        token1.AddressForGeth(),
+		BlockNumber:   blockNumber,
+		SqrtPriceX96:  sqrtPriceX96,
+		Tick:          int(tick.Int64()),
+		Liquidity:     liquidity,
+		TickSpacing:   int(tickSpacing.Int64()),
+		instance:      instance,
+		words:         make(map[int16]*big.Int),
+		liquidityNets: make(map[int]*big.Int),
+	}
+
+	return snapshot, nil
+}
+
+// Implements uniswapV3Math.TickDataProvider
+func (s *PoolSnapshot) TickBitmap(wordPosition int16) (*big.Int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	word, found := s.words[wordPosition]
+	if found {
+		return word, nil
+	}
+
+	if len(s.words) >= maxBitmapWordLoads {
+		return nil, ErrTickDataLimit
+	}
+
+	word, err := s.instance.TickBitmap(&bind.CallOpts{BlockNumber: s.BlockNumber}, wordPosition)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch tick bitmap word %v of pool %v: %w", wordPosition, s.Pool, err)
+	}
+
+	s.words[wordPosition] = word
+	return word, nil
+}
+
+// Implements uniswapV3Math.TickDataProvider
+func (s *PoolSnapshot) LiquidityNet(tick int) (*big.Int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	liquidityNet, found := s.liquidityNets[tick]
+	if found {
+		return liquidityNet, nil
+	}
+
+	tickInfo, err := s.instance.Ticks(&bind.CallOpts{BlockNumber: s.BlockNumber}, big.NewInt(int64(tick)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch tick %v of pool %v: %w", tick, s.Pool, err)
+	}
+
+	s.liquidityNets[tick] = tickInfo.LiquidityNet
+	return tickInfo.LiquidityNet, nil
+}
+
+// Returns the other token of the pool
+func (s *PoolSnapshot) otherToken(token common.Address) common.Address {
+	if token == s.Token0 {
+		return s.Token1
+	}
+
+	return s.Token0
+}
+
+func (s *PoolSnapshot) mathState() *uniswapV3Math.PoolState {
+	return &uniswapV3Math.PoolState{
+		SqrtPriceX96: s.SqrtPriceX96,
+		Tick:         s.Tick,
+		Liquidity:    s.Liquidity,
+		Fee:          s.Pool.Fee,
+		TickSpacing:  s.TickSpacing,
+	}
+}
+
+// Simulates a swap across the initialized ticks of the pool with the exact integer math of the pool.
+// The amount is the input of an exact input swap or the output of an exact output swap.
+// A nil sqrtPriceLimitX96 lets the swap move the price up to the bounds of the pool.
+func (s *PoolSnapshot) Simulate(
+	tokenIn common.Address,
+	amount *big.Int,
+	swapKind models.SwapKind,
+	sqrtPriceLimitX96 *big.Int,
+) (*SimulatedSwap, error) {
+	zeroForOne := tokenIn == s.Token0
+	amountSpecified := new(big.Int).Set(amount)
+	if swapKind == models.ExactOutput {
+		amountSpecified.Neg(amountSpecified)
+	}
+
+	result, err := uniswapV3Math.Swap(s.mathState(), s, zeroForOne, amountSpecified, sqrtPriceLimitX96)
+	if err != nil {
+		return nil, err
+	}
+
+	simulated := &SimulatedSwap{
+		TokenIn:      tokenIn,
+		AmountIn:     result.AmountIn(zeroForOne),
+		AmountOut:    result.AmountOut(zeroForOne),
+		FeeAmount:    result.FeeAmount,
+		SqrtPriceX96: result.SqrtPriceX96,
+		Tick:         result.Tick,
+		TicksCrossed: result.TicksCrossed,
+	}
+
+	if swapKind == models.ExactOutput {
+		simulated.Filled = simulated.AmountOut.Cmp(amount) == 0
+	} else {
+		simulated.Filled = simulated.AmountIn.Cmp(amount) == 0
+	}
+
+	return simulated, nil
+}
+
+// Simulates a swap on the pool of a fee tier at the latest block
+func (h *UniswapV3Handler) SimulateSwap(
+	tokenIn *ethHandler.Token,
+	tokenOut *ethHandler.Token,
+	fee uint,
+	amount *big.Int,
+	swapKind models.SwapKind,
+	sqrtPriceLimitX96 *big.Int,
+) (*SimulatedSwap, error) {
+	pool, err := GetPoolForTokens(h.Network.ChainId, fee, tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := h.LoadPoolSnapshot(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.Simulate(tokenIn.AddressForGeth(), amount, swapKind, sqrtPriceLimitX96)
+}
@@ -0,0 +1,583 @@
+package uniswapV3Handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV3Pool"
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	eventPollInterval  = 15 * time.Second
+	maxEventBlockRange = 5000 // providers cap the block range of eth_getLogs
+)
+
+// Price and in-range liquidity of a pool as of a block
+type CachedPoolState struct {
+	Pool         *PoolWrapper
+	SqrtPriceX96 *big.Int
+	Tick         int
+	Liquidity    *big.Int
+	BlockNumber  uint64 // the state is current as of this block
+}
+
+// One Swap, Mint or Burn event of a pool
+type poolEvent struct {
+	raw  types.Log
+	swap *uniswapV3Pool.UniswapV3PoolSwap
+	mint *uniswapV3Pool.UniswapV3PoolMint
+	burn *uniswapV3Pool.UniswapV3PoolBurn
+}
+
+// In-memory price and in-range liquidity of a set of pools, kept current from their events.
+// Swap events carry the price, tick and liquidity after the swap. Mint and Burn events
+// change the in-range liquidity when the current tick is within the range of the position,
+// so the events of a pool have to be applied in the order they were emitted.
+// The states replaced in recent blocks are journaled so that a reorg rolls the
+// cache back to the fork point before the events of the new branch are applied.
+type PoolStateCache struct {
+	handler   *UniswapV3Handler
+	headers   ethHandler.HeaderReader
+	poolAbi   *abi.ABI
+	instances map[common.Address]*uniswapV3Pool.UniswapV3Pool
+
+	mu        sync.RWMutex
+	states    map[common.Address]*CachedPoolState
+	lastBlock uint64 // last block whose events were processed
+	hashes    *ethHandler.BlockHashWindow
+	journal   map[uint64]map[common.Address]CachedPoolState // states before the first event of each pool in a block
+}
+
+func NewPoolStateCache(handler *UniswapV3Handler, pools []*PoolWrapper) (*PoolStateCache, error) {
+	poolAbi, err := uniswapV3Pool.UniswapV3PoolMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &PoolStateCache{
+		handler:   handler,
+		headers:   handler.Client,
+		poolAbi:   poolAbi,
+		instances: make(map[common.Address]*uniswapV3Pool.UniswapV3Pool),
+		states:    make(map[common.Address]*CachedPoolState),
+		hashes:    ethHandler.NewBlockHashWindow(),
+		journal:   make(map[uint64]map[common.Address]CachedPoolState),
+	}
+
+	for _, pool := range pools {
+		poolAddress := common.HexToAddress(pool.PoolAddress)
+		instance, err := uniswapV3Pool.NewUniswapV3Pool(poolAddress, handler.Client)
+		if err != nil {
+			return nil, err
+		}
+
+		cache.instances[poolAddress] = instance
+		cache.states[poolAddress] = &CachedPoolState{Pool: pool}
+	}
+
+	return cache, nil
+}
+
+// Returns a cache of the registry pools of the handler's network
+func (h *UniswapV3Handler) NewRegistryPoolStateCache() (*PoolStateCache, error) {
+	return NewPoolStateCache(h, h.registryPools())
+}
+
+// Returns a cache of the pools of every fee tier of two tokens
+func (h *UniswapV3Handler) NewTokensPoolStateCache(tokenA *ethHandler.Token, tokenB *ethHandler.Token) (*PoolStateCache, error) {
+	var pools []*PoolWrapper
+	for _, fee := range FeeTiers {
+		pool, err := GetPoolForTokens(h.Network.ChainId, fee, tokenA, tokenB)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+
+	return NewPoolStateCache(h, pools)
+}
+
+// Loads the state of every pool at the latest block, then keeps it current until the
+// context is cancelled. Pools that are not deployed or not initialized are dropped.
+// Events are received over WebSockets, the logs are polled over Https or if the subscription fails.
+func (c *PoolStateCache) Run(ctx context.Context) error {
+	if err := c.load(ctx); err != nil {
+		return err
+	}
+
+	if c.handler.ProviderProtocol == ethHandler.WebSockets {
+		err := c.watchEvents(ctx)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		fmt.Printf("pool event subscription failed, falling back to polling: %v\n", err)
+	}
+
+	return c.pollEvents(ctx)
+}
+
+func (c *PoolStateCache) load(ctx context.Context) error {
+	header, err := c.handler.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	blockNumber := header.Number.Uint64()
+
+	pools := make([]*PoolWrapper, 0, len(c.states))
+	for _, state := range c.states {
+		pools = append(pools, state.Pool)
+	}
+
+	snapshots, err := c.handler.LoadPoolSnapshotsAt(pools, header.Number)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	for i, pool := range pools {
+		poolAddress := common.HexToAddress(pool.PoolAddress)
+		if snapshots[i] == nil {
+			delete(c.instances, poolAddress)
+			delete(c.states, poolAddress)
+			continue
+		}
+
+		state := c.states[poolAddress]
+		state.SqrtPriceX96 = snapshots[i].SqrtPriceX96
+		state.Tick = snapshots[i].Tick
+		state.Liquidity = snapshots[i].Liquidity
+		state.BlockNumber = blockNumber
+	}
+	c.lastBlock = blockNumber
+	c.hashes = ethHandler.NewBlockHashWindow()
+	c.hashes.Add(blockNumber, header.Hash())
+	c.journal = make(map[uint64]map[common.Address]CachedPoolState)
+	c.mu.Unlock()
+
+	return nil
+}
+
+// The three events of every pool are received through a single log subscription,
+// separate Watch subscriptions per event would not preserve their order
+func (c *PoolStateCache) watchEvents(ctx context.Context) error {
+	if len(c.instances) == 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	c.mu.RLock()
+	startBlock := c.lastBlock + 1
+	c.mu.RUnlock()
+
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(startBlock),
+		Topics: [][]common.Hash{{
+			c.poolAbi.Events["Swap"].ID,
+			c.poolAbi.Events["Mint"].ID,
+			c.poolAbi.Events["Burn"].ID,
+		}},
+	}
+	for poolAddress := range c.instances {
+		query.Addresses = append(query.Addresses, poolAddress)
+	}
+
+	logs := make(chan types.Log)
+	sub, err := c.handler.Client.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case log := <-logs:
+			// The logs of the removed blocks are sent again with Removed set,
+			// followed by the logs of the new branch
+			if log.Removed {
+				c.mu.Lock()
+				c.rollback(log.BlockNumber - 1)
+				c.mu.Unlock()
+				continue
+			}
+
+			event, err := c.parseEvent(log)
+			if err != nil {
+				return err
+			}
+
+			c.mu.Lock()
+			c.applyEvent(event)
+			c.mu.Unlock()
+		case err := <-sub.Err():
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *PoolStateCache) parseEvent(log types.Log) (*poolEvent, error) {
+	instance, found := c.instances[log.Address]
+	if !found {
+		return nil, fmt.Errorf("log of unknown pool %v", log.Address)
+	}
+
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("anonymous log of pool %v", log.Address)
+	}
+
+	event := &poolEvent{raw: log}
+	var err error
+	switch log.Topics[0] {
+	case c.poolAbi.Events["Swap"].ID:
+		event.swap, err = instance.ParseSwap(log)
+	case c.poolAbi.Events["Mint"].ID:
+		event.mint, err = instance.ParseMint(log)
+	case c.poolAbi.Events["Burn"].ID:
+		event.burn, err = instance.ParseBurn(log)
+	default:
+		err = fmt.Errorf("unexpected log %v of pool %v", log.Topics[0], log.Address)
+	}
+
+	return event, err
+}
+
+func (c *PoolStateCache) pollEvents(ctx context.Context) error {
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.processNewEvents(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Applies the events between the last processed block and the latest block.
+// Every pool is current as of the latest block afterwards, whether it emitted events or not.
+// The cache is rolled back first if blocks it processed were reorged, or reloaded if
+// the reorg is deeper than its window of recent blocks.
+func (c *PoolStateCache) processNewEvents(ctx context.Context) error {
+	canonical, err := c.rollbackToCanonical(ctx)
+	if err != nil {
+		return err
+	}
+	if !canonical {
+		return c.load(ctx)
+	}
+
+	c.mu.RLock()
+	fromBlock := c.lastBlock + 1
+	c.mu.RUnlock()
+
+	latestHeader, err := c.handler.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	latestBlock := latestHeader.Number.Uint64()
+
+	for fromBlock <= latestBlock {
+		toBlock := fromBlock + maxEventBlockRange - 1
+		if toBlock > latestBlock {
+			toBlock = latestBlock
+		}
+
+		filterOpts := &bind.FilterOpts{Start: fromBlock, End: &toBlock, Context: ctx}
+		for poolAddress, instance := range c.instances {
+			events, err := filterPoolEvents(instance, filterOpts)
+			if err != nil {
+				return fmt.Errorf("unable to filter events of pool %v: %w", poolAddress, err)
+			}
+
+			c.mu.Lock()
+			for _, event := range events {
+				c.applyEvent(event)
+			}
+			c.mu.Unlock()
+		}
+
+		c.mu.Lock()
+		for _, state := range c.states {
+			state.BlockNumber = toBlock
+		}
+		c.lastBlock = toBlock
+		if toBlock == latestBlock {
+			c.hashes.Add(latestBlock, latestHeader.Hash())
+		}
+		c.mu.Unlock()
+
+		fromBlock = toBlock + 1
+	}
+
+	return nil
+}
+
+// Rolls the cache back to the newest processed block still on the canonical chain.
+// Returns false if the reorg is deeper than the window of recent blocks, the cache has to be reloaded then.
+func (c *PoolStateCache) rollbackToCanonical(ctx context.Context) (bool, error) {
+	c.mu.RLock()
+	forkPoint, err := c.hashes.FindForkPoint(ctx, c.headers)
+	c.mu.RUnlock()
+	if errors.Is(err, ethHandler.ErrReorgTooDeep) {
+		fmt.Printf("reloading pool states: %v\n", err)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	c.rollback(forkPoint)
+	c.mu.Unlock()
+
+	return true, nil
+}
+
+// Returns the Swap, Mint and Burn events of a pool in the order they were emitted
+func filterPoolEvents(instance *uniswapV3Pool.UniswapV3Pool, filterOpts *bind.FilterOpts) ([]*poolEvent, error) {
+	var events []*poolEvent
+
+	swaps, err := instance.FilterSwap(filterOpts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	for swaps.Next() {
+		events = append(events, &poolEvent{raw: swaps.Event.Raw, swap: swaps.Event})
+	}
+	err = swaps.Error()
+	swaps.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	mints, err := instance.FilterMint(filterOpts, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	for mints.Next() {
+		events = append(events, &poolEvent{raw: mints.Event.Raw, mint: mints.Event})
+	}
+	err = mints.Error()
+	mints.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	burns, err := instance.FilterBurn(filterOpts, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	for burns.Next() {
+		events = append(events, &poolEvent{raw: burns.Event.Raw, burn: burns.Event})
+	}
+	err = burns.Error()
+	burns.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].raw.BlockNumber != events[j].raw.BlockNumber {
+			return events[i].raw.BlockNumber < events[j].raw.BlockNumber
+		}
+		return events[i].raw.Index < events[j].raw.Index
+	})
+
+	return events, nil
+}
+
+// Must be called with the lock held
+func (c *PoolStateCache) applyEvent(event *poolEvent) {
+	state, found := c.states[event.raw.Address]
+	if !found {
+		return
+	}
+
+	blockJournal, found := c.journal[event.raw.BlockNumber]
+	if !found {
+		blockJournal = make(map[common.Address]CachedPoolState)
+		c.journal[event.raw.BlockNumber] = blockJournal
+	}
+	if _, found := blockJournal[event.raw.Address]; !found {
+		blockJournal[event.raw.Address] = *state
+	}
+	c.hashes.Add(event.raw.BlockNumber, event.raw.BlockHash)
+
+	switch {
+	case event.swap != nil:
+		state.SqrtPriceX96 = event.swap.SqrtPriceX96
+		state.Tick = int(event.swap.Tick.Int64())
+		state.Liquidity = event.swap.Liquidity
+	case event.mint != nil:
+		if positionInRange(state.Tick, event.mint.TickLower, event.mint.TickUpper) {
+			state.Liquidity = new(big.Int).Add(state.Liquidity, event.mint.Amount)
+		}
+	case event.burn != nil:
+		if positionInRange(state.Tick, event.burn.TickLower, event.burn.TickUpper) {
+			state.Liquidity = new(big.Int).Sub(state.Liquidity, event.burn.Amount)
+		}
+	}
+
+	if event.raw.BlockNumber > state.BlockNumber {
+		state.BlockNumber = event.raw.BlockNumber
+	}
+	if event.raw.BlockNumber > c.lastBlock {
+		c.lastBlock = event.raw.BlockNumber
+	}
+
+	for blockNumber := range c.journal {
+		if blockNumber+ethHandler.MaxReorgDepth <= c.lastBlock {
+			delete(c.journal, blockNumber)
+		}
+	}
+}
+
+// Restores the pool states as of a block, undoing the events of the blocks after it.
+// Must be called with the lock held.
+func (c *PoolStateCache) rollback(blockNumber uint64) {
+	if blockNumber >= c.lastBlock {
+		return
+	}
+
+	var blocks []uint64
+	for journaled := range c.journal {
+		if journaled > blockNumber {
+			blocks = append(blocks, journaled)
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] > blocks[j] })
+
+	for _, journaled := range blocks {
+		for poolAddress, previous := range c.journal[journaled] {
+			*c.states[poolAddress] = previous
+		}
+		delete(c.journal, journaled)
+	}
+
+	for _, state := range c.states {
+		if state.BlockNumber > blockNumber {
+			state.BlockNumber = blockNumber
+		}
+	}
+
+	fmt.Printf("reorg: pool states rolled back from block %v to block %v\n", c.lastBlock, blockNumber)
+	c.lastBlock = blockNumber
+	c.hashes.Rollback(blockNumber)
+}
+
+// A position adds to the in-range liquidity when tickLower <= tick < tickUpper
+func positionInRange(tick int, tickLower *big.Int, tickUpper *big.Int) bool {
+	return tickLower.Int64() <= int64(tick) && int64(tick) < tickUpper.Int64()
+}
+
+// Returns a copy of the cached state of a pool, or nil if the pool is not cached or not loaded yet
+func (c *PoolStateCache) State(poolAddress common.Address) *CachedPoolState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	state, found := c.states[poolAddress]
+	if !found || state.SqrtPriceX96 == nil {
+		return nil
+	}
+
+	result := *state
+	return &result
+}
+
+// Returns the last block whose events were applied
+func (c *PoolStateCache) LastBlock() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.lastBlock
+}
+
+// Returns the cached pool with the most in-range liquidity among the fee tiers of two tokens, or nil.
+// With a snapshot, every fee tier has to be cached at the block of the snapshot.
+func (h *UniswapV3Handler) cachedDeepestPool(
+	tokenA *ethHandler.Token,
+	tokenB *ethHandler.Token,
+	snapshot *ethHandler.ChainSnapshot,
+) *CachedPoolState {
+	if h.StateCache == nil {
+		return nil
+	}
+
+	var deepest *CachedPoolState
+	for _, fee := range FeeTiers {
+		pool, err := GetPoolForTokens(h.Network.ChainId, fee, tokenA, tokenB)
+		if err != nil {
+			continue
+		}
+
+		state := h.StateCache.State(common.HexToAddress(pool.PoolAddress))
+		if snapshot != nil && (state == nil || state.BlockNumber != snapshot.Number()) {
+			return nil
+		}
+
+		if state != nil && (deepest == nil || state.Liquidity.Cmp(deepest.Liquidity) > 0) {
+			deepest = state
+		}
+	}
+
+	return deepest
+}
+
+// Returns the price of a pool from its cached state.
+// With a snapshot, the state has to be cached at the block of the snapshot.
+func (h *UniswapV3Handler) cachedPoolPrice(pool *PoolWrapper, snapshot *ethHandler.ChainSnapshot) (models.TickerInfo, bool) {
+	if h.StateCache == nil {
+		return models.TickerInfo{}, false
+	}
+
+	state := h.StateCache.State(common.HexToAddress(pool.PoolAddress))
+	if state == nil || (snapshot != nil && state.BlockNumber != snapshot.Number()) {
+		return models.TickerInfo{}, false
+	}
+
+	ticker, err := h.poolTickerInfo(pool, state.SqrtPriceX96, state.BlockNumber)
+	if err != nil {
+		return models.TickerInfo{}, false
+	}
+
+	return ticker, true
+}
+
+// Splits pools into the prices served from the cache and the pools missing from it
+func (h *UniswapV3Handler) cachedPoolPrices(
+	pools []*PoolWrapper,
+	snapshot *ethHandler.ChainSnapshot,
+) ([]models.TickerInfo, []*PoolWrapper) {
+	var result []models.TickerInfo
+	var uncachedPools []*PoolWrapper
+	for _, pool := range pools {
+		if ticker, found := h.cachedPoolPrice(pool, snapshot); found {
+			result = append(result, ticker)
+			continue
+		}
+		uncachedPools = append(uncachedPools, pool)
+	}
+
+	return result, uncachedPools
+}
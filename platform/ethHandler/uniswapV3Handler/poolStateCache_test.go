@@ -0,0 +1,223 @@
+package uniswapV3Handler
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV3Pool"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Headers of the canonical chain by number
+type fakeHeaders map[uint64]*types.Header
+
+func (f fakeHeaders) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if header, found := f[number.Uint64()]; found {
+		return header, nil
+	}
+
+	return nil, ethereum.NotFound
+}
+
+func (f fakeHeaders) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	for _, header := range f {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+
+	return nil, ethereum.NotFound
+}
+
+// Returns the header of a block of a branch, branches are told apart by their extra data
+func testHeader(number uint64, branch string) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte(branch)}
+}
+
+// Makes blocks from-to of a branch canonical
+func (f fakeHeaders) extend(from uint64, to uint64, branch string) {
+	for number := from; number <= to; number++ {
+		f[number] = testHeader(number, branch)
+	}
+}
+
+var (
+	testPoolA = common.HexToAddress("0x000000000000000000000000000000000000a003")
+	testPoolB = common.HexToAddress("0x000000000000000000000000000000000000b003")
+)
+
+// Returns a cache of pools A and B loaded at block 100 of branch a
+func newTestPoolStateCache(t *testing.T, headers fakeHeaders) *PoolStateCache {
+	t.Helper()
+
+	pools := []*PoolWrapper{{PoolAddress: testPoolA.Hex()}, {PoolAddress: testPoolB.Hex()}}
+	cache, err := NewPoolStateCache(&UniswapV3Handler{EthHandler: &ethHandler.EthHandler{}}, pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.headers = headers
+
+	*cache.states[testPoolA] = CachedPoolState{Pool: pools[0], SqrtPriceX96: big.NewInt(1000), Tick: 10, Liquidity: big.NewInt(500), BlockNumber: 100}
+	*cache.states[testPoolB] = CachedPoolState{Pool: pools[1], SqrtPriceX96: big.NewInt(2000), Tick: 20, Liquidity: big.NewInt(700), BlockNumber: 100}
+	cache.lastBlock = 100
+	cache.hashes.Add(100, testHeader(100, "a").Hash())
+
+	return cache
+}
+
+func testEventLog(pool common.Address, blockNumber uint64) types.Log {
+	return types.Log{Address: pool, BlockNumber: blockNumber, BlockHash: testHeader(blockNumber, "a").Hash()}
+}
+
+func testSwap(pool common.Address, blockNumber uint64, sqrtPriceX96 int64, tick int64, liquidity int64) *poolEvent {
+	return &poolEvent{
+		raw: testEventLog(pool, blockNumber),
+		swap: &uniswapV3Pool.UniswapV3PoolSwap{
+			SqrtPriceX96: big.NewInt(sqrtPriceX96),
+			Tick:         big.NewInt(tick),
+			Liquidity:    big.NewInt(liquidity),
+		},
+	}
+}
+
+func testMint(pool common.Address, blockNumber uint64, tickLower int64, tickUpper int64, amount int64) *poolEvent {
+	return &poolEvent{
+		raw: testEventLog(pool, blockNumber),
+		mint: &uniswapV3Pool.UniswapV3PoolMint{
+			TickLower: big.NewInt(tickLower),
+			TickUpper: big.NewInt(tickUpper),
+			Amount:    big.NewInt(amount),
+		},
+	}
+}
+
+func testBurn(pool common.Address, blockNumber uint64, tickLower int64, tickUpper int64, amount int64) *poolEvent {
+	return &poolEvent{
+		raw: testEventLog(pool, blockNumber),
+		burn: &uniswapV3Pool.UniswapV3PoolBurn{
+			TickLower: big.NewInt(tickLower),
+			TickUpper: big.NewInt(tickUpper),
+			Amount:    big.NewInt(amount),
+		},
+	}
+}
+
+// Applies the events of a block, then marks every pool current as of the block
+func applyTestBlock(cache *PoolStateCache, events []*poolEvent, blockNumber uint64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for _, event := range events {
+		cache.applyEvent(event)
+	}
+	for _, state := range cache.states {
+		state.BlockNumber = blockNumber
+	}
+	cache.lastBlock = blockNumber
+}
+
+// Square root price, tick and liquidity of pools A and B
+type testPoolStates [2][3]int64
+
+func cachedTestPoolStates(cache *PoolStateCache) testPoolStates {
+	var states testPoolStates
+	for i, pool := range []common.Address{testPoolA, testPoolB} {
+		state := cache.State(pool)
+		states[i] = [3]int64{state.SqrtPriceX96.Int64(), int64(state.Tick), state.Liquidity.Int64()}
+	}
+
+	return states
+}
+
+func TestPoolStateCacheRollback(t *testing.T) {
+	// Events of blocks 101 to 103 of branch a, and the pool states after each block
+	blocks := map[uint64][]*poolEvent{
+		101: {testMint(testPoolA, 101, 0, 100, 50), testMint(testPoolA, 101, 200, 300, 80)},
+		102: {testSwap(testPoolA, 102, 1100, 150, 300), testBurn(testPoolA, 102, 100, 200, 100), testSwap(testPoolB, 102, 2100, 25, 800)},
+		103: {testBurn(testPoolB, 103, 0, 100, 50)},
+	}
+	states := map[uint64]testPoolStates{
+		100: {{1000, 10, 500}, {2000, 20, 700}},
+		101: {{1000, 10, 550}, {2000, 20, 700}},
+		102: {{1100, 150, 200}, {2100, 25, 800}},
+		103: {{1100, 150, 200}, {2100, 25, 750}},
+	}
+
+	tests := []struct {
+		name       string
+		forkAt     uint64 // branch b replaces the blocks after it, 0 for no reorg
+		wantReload bool
+		wantBlock  uint64
+	}{
+		{name: "no reorg", wantBlock: 103},
+		{name: "newest block replaced", forkAt: 102, wantBlock: 102},
+		{name: "swap and burn of a pool replaced", forkAt: 101, wantBlock: 101},
+		{name: "every block with events replaced", forkAt: 100, wantBlock: 100},
+		{name: "every block of the window replaced", forkAt: 99, wantReload: true, wantBlock: 103},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := fakeHeaders{}
+			headers.extend(100, 103, "a")
+			cache := newTestPoolStateCache(t, headers)
+			for number := uint64(101); number <= 103; number++ {
+				applyTestBlock(cache, blocks[number], number)
+			}
+			if test.forkAt > 0 {
+				headers.extend(test.forkAt+1, 104, "b")
+			}
+
+			canonical, err := cache.rollbackToCanonical(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if canonical == test.wantReload {
+				t.Fatalf("got reload %v, want %v", !canonical, test.wantReload)
+			}
+
+			if got := cachedTestPoolStates(cache); got != states[test.wantBlock] {
+				t.Errorf("got states %v, want %v", got, states[test.wantBlock])
+			}
+			if cache.LastBlock() != test.wantBlock {
+				t.Errorf("got last block %v, want %v", cache.LastBlock(), test.wantBlock)
+			}
+			for _, pool := range []common.Address{testPoolA, testPoolB} {
+				if blockNumber := cache.State(pool).BlockNumber; blockNumber != test.wantBlock {
+					t.Errorf("pool %v: got block %v, want %v", pool, blockNumber, test.wantBlock)
+				}
+			}
+		})
+	}
+}
+
+// A reorg below the journaled blocks reloads the cache instead of rolling back
+func TestPoolStateCacheReorgTooDeep(t *testing.T) {
+	headers := fakeHeaders{}
+	newest := uint64(100 + ethHandler.MaxReorgDepth + 5)
+	headers.extend(100, newest, "a")
+	cache := newTestPoolStateCache(t, headers)
+	for number := uint64(101); number <= newest; number++ {
+		applyTestBlock(cache, []*poolEvent{testSwap(testPoolA, number, int64(number), 10, 500)}, number)
+	}
+
+	if len(cache.journal) > ethHandler.MaxReorgDepth {
+		t.Errorf("journal of %v blocks, want at most %v", len(cache.journal), ethHandler.MaxReorgDepth)
+	}
+
+	headers.extend(101, newest+1, "b")
+	canonical, err := cache.rollbackToCanonical(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canonical {
+		t.Fatal("rolled back, want reload")
+	}
+	if cache.LastBlock() != newest {
+		t.Errorf("got last block %v, want %v", cache.LastBlock(), newest)
+	}
+}
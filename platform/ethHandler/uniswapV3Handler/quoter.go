@@ -0,0 +1,206 @@
+package uniswapV3Handler
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV3QuoterV2"
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrQuoteMismatch = errors.New("local simulation does not match the on-chain quote")
+
+// TODO: Parse from json or config file
+var quoterV2AddressMap = map[ethHandler.ChainId]string{
+	1:     "0x61fFE014bA17989E743c5F6cB21bF9697530B21e",
+	5:     "0x61fFE014bA17989E743c5F6cB21bF9697530B21e",
+	10:    "0x61fFE014bA17989E743c5F6cB21bF9697530B21e",
+	137:   "0x61fFE014bA17989E743c5F6cB21bF9697530B21e",
+	80001: "0x61fFE014bA17989E743c5F6cB21bF9697530B21e",
+	42161: "0x61fFE014bA17989E743c5F6cB21bF9697530B21e",
+	42220: "0x82825d0554fA07f7FC52Ab63c961F330fdEFa8E8",
+}
+
+func GetQuoterV2Address(chainId ethHandler.ChainId) (common.Address, error) {
+	address, found := quoterV2AddressMap[chainId]
+	if !found {
+		return common.Address{}, fmt.Errorf("no QuoterV2 on chainId=%v", chainId)
+	}
+
+	return common.HexToAddress(address), nil
+}
+
+func HasQuoterV2(chainId ethHandler.ChainId) bool {
+	_, found := quoterV2AddressMap[chainId]
+	return found
+}
+
+// Result of a QuoterV2 call. The lists hold one entry per pool of the path.
+type OnchainQuote struct {
+	AmountIn                    *big.Int
+	AmountOut                   *big.Int
+	SqrtPriceX96AfterList       []*big.Int
+	InitializedTicksCrossedList []uint32
+	GasEstimate                 *big.Int
+}
+
+// Local simulation of a swap compared with the QuoterV2 quote at the same block
+type QuoteComparison struct {
+	Simulated *SimulatedSwap
+	Onchain   *OnchainQuote
+	Mismatch  bool // amounts or final price differ
+}
+
+// Quotes a swap along a path of pools with QuoterV2 through eth_call.
+// The quoter executes the swap and reverts, so the quote reflects the on-chain pool code exactly.
+// A sqrtPriceLimitX96 is only supported by single pool quotes.
+func (h *UniswapV3Handler) Quote(params *SwapParams, callOpts *bind.CallOpts) (*OnchainQuote, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	quoterAddress, err := GetQuoterV2Address(h.Network.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := uniswapV3QuoterV2.NewUniswapV3QuoterV2(quoterAddress, h.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	// The quote functions are not view functions, they have to be called through the raw contract
+	quoter := &uniswapV3QuoterV2.UniswapV3QuoterV2Raw{Contract: instance}
+
+	sqrtPriceLimitX96 := params.SqrtPriceLimitX96
+	if sqrtPriceLimitX96 == nil {
+		sqrtPriceLimitX96 = new(big.Int)
+	}
+
+	var out []interface{}
+	singlePool := len(params.Fees) == 1
+	exactInput := params.SwapKind == models.ExactInput
+
+	switch {
+	case singlePool && exactInput:
+		err = quoter.Call(callOpts, &out, "quoteExactInputSingle", uniswapV3QuoterV2.IQuoterV2QuoteExactInputSingleParams{
+			TokenIn:           params.Tokens[0],
+			TokenOut:          params.Tokens[1],
+			AmountIn:          params.Amount,
+			Fee:               big.NewInt(int64(params.Fees[0])),
+			SqrtPriceLimitX96: sqrtPriceLimitX96,
+		})
+	case singlePool:
+		err = quoter.Call(callOpts, &out, "quoteExactOutputSingle", uniswapV3QuoterV2.IQuoterV2QuoteExactOutputSingleParams{
+			TokenIn:           params.Tokens[0],
+			TokenOut:          params.Tokens[1],
+			Amount:            params.Amount,
+			Fee:               big.NewInt(int64(params.Fees[0])),
+			SqrtPriceLimitX96: sqrtPriceLimitX96,
+		})
+	case exactInput:
+		path, pathErr := EncodePath(params.Tokens, params.Fees)
+		if pathErr != nil {
+			return nil, pathErr
+		}
+		err = quoter.Call(callOpts, &out, "quoteExactInput", path, params.Amount)
+	default:
+		tokens, fees := reversePath(params.Tokens, params.Fees)
+		path, pathErr := EncodePath(tokens, fees)
+		if pathErr != nil {
+			return nil, pathErr
+		}
+		err = quoter.Call(callOpts, &out, "quoteExactOutput", path, params.Amount)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("QuoterV2 call failed: %w", err)
+	}
+
+	quote := &OnchainQuote{
+		AmountIn:    params.Amount,
+		AmountOut:   out[0].(*big.Int),
+		GasEstimate: out[3].(*big.Int),
+	}
+
+	if !exactInput {
+		quote.AmountIn, quote.AmountOut = out[0].(*big.Int), params.Amount
+	}
+
+	if singlePool {
+		quote.SqrtPriceX96AfterList = []*big.Int{out[1].(*big.Int)}
+		quote.InitializedTicksCrossedList = []uint32{out[2].(uint32)}
+	} else {
+		quote.SqrtPriceX96AfterList = out[1].([]*big.Int)
+		quote.InitializedTicksCrossedList = out[2].([]uint32)
+	}
+
+	return quote, nil
+}
+
+// Simulates a swap on a pool snapshot and quotes the same swap with QuoterV2 at the block of the snapshot.
+// The comparison is flagged as a mismatch if the amounts or the price after the swap differ.
+func (h *UniswapV3Handler) CompareQuote(
+	snapshot *PoolSnapshot,
+	tokenIn common.Address,
+	amount *big.Int,
+	swapKind models.SwapKind,
+) (*QuoteComparison, error) {
+	simulated, err := snapshot.Simulate(tokenIn, amount, swapKind, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &SwapParams{
+		Tokens:      []common.Address{tokenIn, snapshot.otherToken(tokenIn)},
+		Fees:        []uint{snapshot.Pool.Fee},
+		SwapKind:    swapKind,
+		Amount:      amount,
+		AmountLimit: new(big.Int),
+	}
+
+	onchain, err := h.Quote(params, &bind.CallOpts{BlockNumber: snapshot.BlockNumber})
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &QuoteComparison{
+		Simulated: simulated,
+		Onchain:   onchain,
+		Mismatch: simulated.AmountIn.Cmp(onchain.AmountIn) != 0 ||
+			simulated.AmountOut.Cmp(onchain.AmountOut) != 0 ||
+			simulated.SqrtPriceX96.Cmp(onchain.SqrtPriceX96AfterList[0]) != 0,
+	}
+
+	return comparison, nil
+}
+
+// Compares every pool of a route with QuoterV2 and returns ErrQuoteMismatch if a simulation is off
+func (h *UniswapV3Handler) VerifyRoute(route *PoolRoute) error {
+	for _, allocation := range route.Allocations {
+		amount := allocation.AmountIn
+		if route.SwapKind == models.ExactOutput {
+			amount = allocation.AmountOut
+		}
+
+		comparison, err := h.CompareQuote(allocation.Pool, route.TokenIn, amount, route.SwapKind)
+		if err != nil {
+			return err
+		}
+
+		if comparison.Mismatch {
+			return fmt.Errorf("%w on pool %v: simulated in=%v out=%v, quoted in=%v out=%v",
+				ErrQuoteMismatch,
+				allocation.Pool.Pool,
+				comparison.Simulated.AmountIn,
+				comparison.Simulated.AmountOut,
+				comparison.Onchain.AmountIn,
+				comparison.Onchain.AmountOut)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,466 @@
+package uniswapV3Handler
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV3SwapRouter"
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV3SwapRouter02"
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	txMineWaitTimeout = 5 * time.Minute
+	feeTierSize       = 3 // pool fees are encoded as uint24 in paths
+)
+
+type SwapRouterVersion uint
+
+const (
+	SwapRouter      SwapRouterVersion = iota // v3-periphery SwapRouter, the swap params include the deadline
+	SwapRouter02                             // swap-router-contracts SwapRouter02, the deadline is passed to multicall
+	UniversalRouter                          // universal-router UniversalRouter, the input token is allowed through Permit2
+)
+
+func (v SwapRouterVersion) String() string {
+	return [...]string{
+		"SwapRouter",
+		"SwapRouter02",
+		"UniversalRouter"}[v]
+}
+
+// TODO: Parse from json or config file
+var swapRouterAddressMap = map[SwapRouterVersion]map[ethHandler.ChainId]string{
+	SwapRouter: {
+		1:     "0xE592427A0AEce92De3Edee1F18E0157C05861564",
+		5:     "0xE592427A0AEce92De3Edee1F18E0157C05861564",
+		10:    "0xE592427A0AEce92De3Edee1F18E0157C05861564",
+		137:   "0xE592427A0AEce92De3Edee1F18E0157C05861564",
+		80001: "0xE592427A0AEce92De3Edee1F18E0157C05861564",
+		42161: "0xE592427A0AEce92De3Edee1F18E0157C05861564",
+	},
+	SwapRouter02: {
+		1:     "0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45",
+		5:     "0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45",
+		10:    "0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45",
+		137:   "0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45",
+		80001: "0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45",
+		42161: "0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45",
+		42220: "0x5615CDAb10dc425a742d643d949a7F474C01abc4",
+	},
+	UniversalRouter: {
+		1:     "0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD",
+		5:     "0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD",
+		10:    "0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD",
+		137:   "0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD",
+		80001: "0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD",
+		42161: "0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD",
+		42220: "0x643770E279d5D0733F21d6DC03A8efbABf3255B4",
+	},
+}
+
+func GetSwapRouterAddress(version SwapRouterVersion, chainId ethHandler.ChainId) (common.Address, error) {
+	address, found := swapRouterAddressMap[version][chainId]
+	if !found {
+		return common.Address{}, fmt.Errorf("no %v on chainId=%v", version, chainId)
+	}
+
+	return common.HexToAddress(address), nil
+}
+
+type SwapParams struct {
+	Tokens            []common.Address // tokens along the path, starting with the input token
+	Fees              []uint           // fee tier of every pool along the path
+	SwapKind          models.SwapKind
+	Amount            *big.Int // input of an exact input swap, output of an exact output swap
+	AmountLimit       *big.Int // minimum output of an exact input swap, maximum input of an exact output swap
+	SqrtPriceLimitX96 *big.Int // price limit of single pool swaps, nil for no limit
+	Recipient         common.Address
+	Deadline          time.Time
+	NativeIn          bool // pay with native ETH, the router wraps it
+	NativeOut         bool // receive native ETH, the router unwraps it
+}
+
+func (p *SwapParams) validate() error {
+	if len(p.Tokens) < 2 || len(p.Fees) != len(p.Tokens)-1 {
+		return errors.New("swap path must have one fee tier between every pair of tokens")
+	}
+
+	if p.Amount == nil || p.AmountLimit == nil {
+		return errors.New("swap amount and amount limit are required")
+	}
+
+	if p.SqrtPriceLimitX96 != nil && p.SqrtPriceLimitX96.Sign() != 0 && len(p.Fees) > 1 {
+		return errors.New("price limits are only supported for single pool swaps")
+	}
+
+	return nil
+}
+
+func (p *SwapParams) sameSwapAs(other *SwapParams) bool {
+	return p.Tokens[0] == other.Tokens[0] &&
+		p.Tokens[len(p.Tokens)-1] == other.Tokens[len(other.Tokens)-1] &&
+		p.SwapKind == other.SwapKind &&
+		p.Recipient == other.Recipient &&
+		p.NativeIn == other.NativeIn &&
+		p.NativeOut == other.NativeOut
+}
+
+// Encodes a swap path as expected by exactInput: token0 ++ fee0 ++ token1 ++ fee1 ++ token2 ...
+// The path of exactOutput is encoded in reverse, starting with the output token.
+func EncodePath(tokens []common.Address, fees []uint) ([]byte, error) {
+	if len(tokens) < 2 || len(fees) != len(tokens)-1 {
+		return nil, errors.New("path must have one fee tier between every pair of tokens")
+	}
+
+	path := make([]byte, 0, len(tokens)*common.AddressLength+len(fees)*feeTierSize)
+	for i, token := range tokens {
+		path = append(path, token.Bytes()...)
+		if i < len(fees) {
+			fee := fees[i]
+			path = append(path, byte(fee>>16), byte(fee>>8), byte(fee))
+		}
+	}
+
+	return path, nil
+}
+
+func reversePath(tokens []common.Address, fees []uint) ([]common.Address, []uint) {
+	reversedTokens := make([]common.Address, len(tokens))
+	for i, token := range tokens {
+		reversedTokens[len(tokens)-1-i] = token
+	}
+
+	reversedFees := make([]uint, len(fees))
+	for i, fee := range fees {
+		reversedFees[len(fees)-1-i] = fee
+	}
+
+	return reversedTokens, reversedFees
+}
+
+// Encodes the router calls of one or more swaps of the same tokens and returns them
+// with the ETH value to send. Native ETH outputs are sent to the router and unwrapped
+// to the recipient, unspent native ETH inputs of exact output swaps are refunded.
+func (h *UniswapV3Handler) encodeSwapCalls(swaps []*SwapParams, routerAddress common.Address) ([][]byte, *big.Int, error) {
+	routerAbi, err := h.swapRouterAbi()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	first := swaps[0]
+	var calls [][]byte
+	value := new(big.Int)
+	amountMinimum := new(big.Int)
+	for _, params := range swaps {
+		recipient := params.Recipient
+		if params.NativeOut {
+			recipient = routerAddress
+		}
+
+		swapCall, err := h.encodeSwapCall(routerAbi, params, recipient)
+		if err != nil {
+			return nil, nil, err
+		}
+		calls = append(calls, swapCall)
+
+		if params.SwapKind == models.ExactOutput {
+			value.Add(value, params.AmountLimit)
+			amountMinimum.Add(amountMinimum, params.Amount)
+		} else {
+			value.Add(value, params.Amount)
+			amountMinimum.Add(amountMinimum, params.AmountLimit)
+		}
+	}
+
+	if !first.NativeIn {
+		value.SetUint64(0)
+	} else if first.SwapKind == models.ExactOutput {
+		refundCall, err := routerAbi.Pack("refundETH")
+		if err != nil {
+			return nil, nil, err
+		}
+		calls = append(calls, refundCall)
+	}
+
+	if first.NativeOut {
+		unwrapCall, err := routerAbi.Pack("unwrapWETH9", amountMinimum, first.Recipient)
+		if err != nil {
+			return nil, nil, err
+		}
+		calls = append(calls, unwrapCall)
+	}
+
+	return calls, value, nil
+}
+
+func (h *UniswapV3Handler) encodeSwapCall(routerAbi *abi.ABI, params *SwapParams, recipient common.Address) ([]byte, error) {
+	sqrtPriceLimitX96 := params.SqrtPriceLimitX96
+	if sqrtPriceLimitX96 == nil {
+		sqrtPriceLimitX96 = new(big.Int)
+	}
+
+	deadline := big.NewInt(params.Deadline.Unix())
+	singlePool := len(params.Fees) == 1
+	exactInput := params.SwapKind == models.ExactInput
+
+	tokens, fees := params.Tokens, params.Fees
+	if !exactInput {
+		tokens, fees = reversePath(tokens, fees)
+	}
+
+	path, err := EncodePath(tokens, fees)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.RouterVersion == SwapRouter {
+		switch {
+		case singlePool && exactInput:
+			return routerAbi.Pack("exactInputSingle", uniswapV3SwapRouter.ISwapRouterExactInputSingleParams{
+				TokenIn:           params.Tokens[0],
+				TokenOut:          params.Tokens[1],
+				Fee:               big.NewInt(int64(params.Fees[0])),
+				Recipient:         recipient,
+				Deadline:          deadline,
+				AmountIn:          params.Amount,
+				AmountOutMinimum:  params.AmountLimit,
+				SqrtPriceLimitX96: sqrtPriceLimitX96,
+			})
+		case singlePool:
+			return routerAbi.Pack("exactOutputSingle", uniswapV3SwapRouter.ISwapRouterExactOutputSingleParams{
+				TokenIn:           params.Tokens[0],
+				TokenOut:          params.Tokens[1],
+				Fee:               big.NewInt(int64(params.Fees[0])),
+				Recipient:         recipient,
+				Deadline:          deadline,
+				AmountOut:         params.Amount,
+				AmountInMaximum:   params.AmountLimit,
+				SqrtPriceLimitX96: sqrtPriceLimitX96,
+			})
+		case exactInput:
+			return routerAbi.Pack("exactInput", uniswapV3SwapRouter.ISwapRouterExactInputParams{
+				Path:             path,
+				Recipient:        recipient,
+				Deadline:         deadline,
+				AmountIn:         params.Amount,
+				AmountOutMinimum: params.AmountLimit,
+			})
+		default:
+			return routerAbi.Pack("exactOutput", uniswapV3SwapRouter.ISwapRouterExactOutputParams{
+				Path:            path,
+				Recipient:       recipient,
+				Deadline:        deadline,
+				AmountOut:       params.Amount,
+				AmountInMaximum: params.AmountLimit,
+			})
+		}
+	}
+
+	switch {
+	case singlePool && exactInput:
+		return routerAbi.Pack("exactInputSingle", uniswapV3SwapRouter02.IV3SwapRouterExactInputSingleParams{
+			TokenIn:           params.Tokens[0],
+			TokenOut:          params.Tokens[1],
+			Fee:               big.NewInt(int64(params.Fees[0])),
+			Recipient:         recipient,
+			AmountIn:          params.Amount,
+			AmountOutMinimum:  params.AmountLimit,
+			SqrtPriceLimitX96: sqrtPriceLimitX96,
+		})
+	case singlePool:
+		return routerAbi.Pack("exactOutputSingle", uniswapV3SwapRouter02.IV3SwapRouterExactOutputSingleParams{
+			TokenIn:           params.Tokens[0],
+			TokenOut:          params.Tokens[1],
+			Fee:               big.NewInt(int64(params.Fees[0])),
+			Recipient:         recipient,
+			AmountOut:         params.Amount,
+			AmountInMaximum:   params.AmountLimit,
+			SqrtPriceLimitX96: sqrtPriceLimitX96,
+		})
+	case exactInput:
+		return routerAbi.Pack("exactInput", uniswapV3SwapRouter02.IV3SwapRouterExactInputParams{
+			Path:             path,
+			Recipient:        recipient,
+			AmountIn:         params.Amount,
+			AmountOutMinimum: params.AmountLimit,
+		})
+	default:
+		return routerAbi.Pack("exactOutput", uniswapV3SwapRouter02.IV3SwapRouterExactOutputParams{
+			Path:            path,
+			Recipient:       recipient,
+			AmountOut:       params.Amount,
+			AmountInMaximum: params.AmountLimit,
+		})
+	}
+}
+
+func (h *UniswapV3Handler) swapRouterAbi() (*abi.ABI, error) {
+	if h.RouterVersion == SwapRouter {
+		return uniswapV3SwapRouter.UniswapV3SwapRouterMetaData.GetAbi()
+	}
+
+	return uniswapV3SwapRouter02.UniswapV3SwapRouter02MetaData.GetAbi()
+}
+
+func (h *UniswapV3Handler) approveToken(wallet *ethHandler.Wallet, token *ethHandler.Token, spender common.Address) error {
+	tokenHandler, err := ethHandler.NewERC20Handler(h.EthHandler, token)
+	if err != nil {
+		panic(err)
+	}
+
+	err = tokenHandler.MaxApprove(wallet, spender, true)
+	if err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+// Allows the router to spend an amount of the input token of a swap.
+// With UsePermits, tokens implementing EIP-2612 are permitted within the swap transaction:
+// the returned selfPermitIfNecessary call has to be batched before the swaps.
+// Other tokens are approved with separate transactions and no call is returned.
+func (h *UniswapV3Handler) authorizeTokenIn(
+	wallet *ethHandler.Wallet,
+	token *ethHandler.Token,
+	routerAddress common.Address,
+	amount *big.Int,
+	deadline time.Time,
+) ([]byte, error) {
+	tokenHandler, err := ethHandler.NewERC20Handler(h.EthHandler, token)
+	if err != nil {
+		return nil, err
+	}
+
+	allowance, err := tokenHandler.Allowance(wallet.Address, routerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowance.Cmp(amount) >= 0 {
+		return nil, nil
+	}
+
+	if h.UsePermits {
+		permit, err := tokenHandler.SignPermit(wallet, routerAddress, amount, deadline)
+		if err == nil {
+			return h.encodeSelfPermitCall(permit)
+		}
+		if !errors.Is(err, ethHandler.ErrPermitNotSupported) {
+			return nil, err
+		}
+	}
+
+	// TODO: Pre-approve tokens on init
+	return nil, h.approveToken(wallet, token, routerAddress)
+}
+
+// Encodes a permit as a router call. selfPermitIfNecessary skips the permit if the allowance
+// is already set, so the swaps still go through if someone else submits the permit first.
+func (h *UniswapV3Handler) encodeSelfPermitCall(permit *ethHandler.Permit) ([]byte, error) {
+	routerAbi, err := h.swapRouterAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return routerAbi.Pack("selfPermitIfNecessary", permit.Token, permit.Value, permit.Deadline, permit.V, permit.R, permit.S)
+}
+
+// Returns the most the swaps may spend: the inputs of exact input swaps and the input limits of exact output swaps
+func swapsMaxAmountIn(swaps []*SwapParams) *big.Int {
+	total := new(big.Int)
+	for _, params := range swaps {
+		if params.SwapKind == models.ExactOutput {
+			total.Add(total, params.AmountLimit)
+		} else {
+			total.Add(total, params.Amount)
+		}
+	}
+
+	return total
+}
+
+// Sends a swap through the swap router with the calls batched in a multicall.
+// The input token is permitted or approved for the router unless it is paid with native ETH.
+func (h *UniswapV3Handler) ExecuteSwap(wallet *ethHandler.Wallet, params *SwapParams) (*types.Transaction, error) {
+	return h.ExecuteSwaps(wallet, []*SwapParams{params})
+}
+
+// Sends swaps of the same tokens in a single multicall, e.g. an order split across fee tiers
+func (h *UniswapV3Handler) ExecuteSwaps(wallet *ethHandler.Wallet, swaps []*SwapParams) (*types.Transaction, error) {
+	if len(swaps) == 0 {
+		return nil, errors.New("no swap to execute")
+	}
+
+	first := swaps[0]
+	for _, params := range swaps {
+		if err := params.validate(); err != nil {
+			return nil, err
+		}
+
+		if !params.sameSwapAs(first) {
+			return nil, errors.New("batched swaps must share their tokens, kind, recipient and native ETH handling")
+		}
+	}
+
+	routerAddress, err := GetSwapRouterAddress(h.RouterVersion, h.Network.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.RouterVersion == UniversalRouter {
+		return h.executeUniversalRouterSwaps(wallet, swaps, routerAddress)
+	}
+
+	var calls [][]byte
+	if !first.NativeIn {
+		inputToken, err := ethHandler.GetTokenByAddress(h.Network.ChainId, first.Tokens[0].Hex())
+		if err != nil {
+			return nil, err
+		}
+
+		permitCall, err := h.authorizeTokenIn(wallet, inputToken, routerAddress, swapsMaxAmountIn(swaps), first.Deadline)
+		if err != nil {
+			return nil, err
+		}
+
+		if permitCall != nil {
+			calls = append(calls, permitCall)
+		}
+	}
+
+	swapCalls, value, err := h.encodeSwapCalls(swaps, routerAddress)
+	if err != nil {
+		return nil, err
+	}
+	calls = append(calls, swapCalls...)
+
+	auth, err := h.TransactOpts(wallet, value, h.SendSwapTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.RouterVersion == SwapRouter {
+		instance, err := uniswapV3SwapRouter.NewUniswapV3SwapRouter(routerAddress, h.Client)
+		if err != nil {
+			return nil, err
+		}
+
+		return instance.Multicall(auth, calls)
+	}
+
+	instance, err := uniswapV3SwapRouter02.NewUniswapV3SwapRouter02(routerAddress, h.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	// multicall(uint256 deadline, bytes[] data)
+	return instance.Multicall0(auth, big.NewInt(first.Deadline.Unix()), calls)
+}
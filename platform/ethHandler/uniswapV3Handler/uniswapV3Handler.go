@@ -3,12 +3,12 @@ package uniswapV3Handler
 import (
 	"fmt"
 	"math/big"
+	"os"
 	"time"
 
 	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapV3Pool"
 	"github.com/Opulentia-Trading/Arbitrage/models"
 	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	gethMath "github.com/ethereum/go-ethereum/common/math"
 )
@@ -20,6 +20,21 @@ var q192 = gethMath.BigPow(2, 192)
 // Implements the Platform interface
 type UniswapV3Handler struct {
 	*ethHandler.EthHandler
+	RouterVersion SwapRouterVersion // router used to execute swaps
+	SwapNativeETH bool              // use native ETH as the input/output of a swap
+	SendSwapTx    bool              // broadcast swap tx on blockchain
+	MaxPoolSplits int               // maximum number of fee tiers an order is split across
+	VerifyQuotes  bool              // compare the simulated swaps of an order with QuoterV2 before sending it, if the chain has one
+	UsePermits    bool              // sign EIP-2612 permits for the router instead of sending approve transactions
+	StateCache    *PoolStateCache   // serves the prices of the cached pools from memory while it runs
+
+	// Allows the Universal Router to spend the input of swaps through Permit2
+	Permit2 *ethHandler.Permit2AllowanceManager
+
+	// Orders are rejected if the spot price of a pool deviates from its TWAP,
+	// the check is disabled if TwapWindow is zero
+	TwapWindow          time.Duration
+	MaxTwapDeviationBps uint
 }
 
 func NewUniswapV3Handler() (*UniswapV3Handler, error) {
@@ -49,7 +64,26 @@ func NewUniswapV3Handler() (*UniswapV3Handler, error) {
 		panic(err)
 	}
 
-	return &UniswapV3Handler{ethHandlerInst}, nil
+	permit2Manager, err := ethHandlerInst.NewPermit2AllowanceManager()
+	if err != nil {
+		panic(err)
+	}
+
+	handler := &UniswapV3Handler{
+		EthHandler:    ethHandlerInst,
+		RouterVersion: SwapRouter02,
+		SwapNativeETH: false,
+		SendSwapTx:    false,
+		MaxPoolSplits: defaultMaxPoolSplits,
+		VerifyQuotes:  true,
+		UsePermits:    true,
+		Permit2:       permit2Manager,
+
+		TwapWindow:          defaultTwapWindow,
+		MaxTwapDeviationBps: defaultMaxTwapDeviationBps,
+	}
+
+	return handler, nil
 }
 
 func isSupportedNetwork(network *ethHandler.EvmNetwork) bool {
@@ -79,33 +113,129 @@ func (h *UniswapV3Handler) TestConnection() (string, error) {
 	return h.EthHandler.TestConnection()
 }
 
+// Returns the prices of the registry pools. Pools missing from the state cache
+// are read in one multicall at the latest block.
 func (h *UniswapV3Handler) FetchTickerInfoAll() ([]models.TickerInfo, error) {
+	result, uncachedPools := h.cachedPoolPrices(h.registryPools(), nil)
+	if len(uncachedPools) == 0 {
+		return result, nil
+	}
+
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		panic(err)
+	}
+
+	tickers, err := h.fetchPoolPrices(uncachedPools, snapshot)
+	if err != nil {
+		panic(err)
+	}
+
+	return append(result, tickers...), nil
+}
+
+// Returns the prices of the registry pools at the block of a snapshot
+func (h *UniswapV3Handler) FetchTickerInfoAllAt(snapshot *ethHandler.ChainSnapshot) ([]models.TickerInfo, error) {
+	result, uncachedPools := h.cachedPoolPrices(h.registryPools(), snapshot)
+	tickers, err := h.fetchPoolPrices(uncachedPools, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result, tickers...), nil
+}
+
+// Reads the prices of pools in one multicall at the block of a snapshot
+func (h *UniswapV3Handler) fetchPoolPrices(pools []*PoolWrapper, snapshot *ethHandler.ChainSnapshot) ([]models.TickerInfo, error) {
+	if len(pools) == 0 {
+		return nil, nil
+	}
+
+	poolSnapshots, err := h.LoadPoolSnapshotsAt(pools, snapshot.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
 	var result []models.TickerInfo
+	for i, pool := range pools {
+		if poolSnapshots[i] == nil {
+			return nil, fmt.Errorf("%w: %v", ErrPoolNotInitialized, pool)
+		}
+
+		ticker, err := h.poolTickerInfo(pool, poolSnapshots[i].SqrtPriceX96, snapshot.Number())
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ticker)
+	}
+
+	return result, nil
+}
 
+// Returns the registry pools of the handler's network
+func (h *UniswapV3Handler) registryPools() []*PoolWrapper {
+	var pools []*PoolWrapper
 	for _, pool := range poolsMap {
 		if pool.ChainId == h.Network.ChainId {
-			ticker, err := h.getPoolPrice(pool)
-			if err != nil {
-				panic(err)
-			}
-			result = append(result, ticker)
+			pools = append(pools, pool)
 		}
 	}
 
-	return result, nil
+	return pools
 }
 
+// Returns the price of the pool with the most in-range liquidity among the fee tiers of the pair.
+// The fee tier of the pool is reported as the commission.
 func (h *UniswapV3Handler) FetchTickerInfo(base string, quote string) (models.TickerInfo, error) {
-	// TODO: Handle other pool fee tiers
-	// Default to the 0.3% fee tier
-	var poolFee uint = 3000
+	baseToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, base)
+	if err != nil {
+		panic(err)
+	}
+
+	quoteToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, quote)
+	if err != nil {
+		panic(err)
+	}
+
+	if state := h.cachedDeepestPool(baseToken, quoteToken, nil); state != nil {
+		return h.poolTickerInfo(state.Pool, state.SqrtPriceX96, state.BlockNumber)
+	}
+
+	snapshot, err := h.LatestSnapshot()
+	if err != nil {
+		panic(err)
+	}
 
-	pool, err := GetPool(h.Network.ChainId, poolFee, base, quote)
+	deepest, err := h.FindDeepestPoolAt(snapshot, baseToken, quoteToken)
 	if err != nil {
 		panic(err)
 	}
 
-	return h.getPoolPrice(pool)
+	return h.poolTickerInfo(deepest.Pool, deepest.SqrtPriceX96, snapshot.Number())
+}
+
+// Returns the price of the deepest pool of the pair at the block of a snapshot
+func (h *UniswapV3Handler) FetchTickerInfoAt(snapshot *ethHandler.ChainSnapshot, base string, quote string) (models.TickerInfo, error) {
+	baseToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, base)
+	if err != nil {
+		return models.TickerInfo{}, err
+	}
+
+	quoteToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, quote)
+	if err != nil {
+		return models.TickerInfo{}, err
+	}
+
+	if state := h.cachedDeepestPool(baseToken, quoteToken, snapshot); state != nil {
+		return h.poolTickerInfo(state.Pool, state.SqrtPriceX96, state.BlockNumber)
+	}
+
+	deepest, err := h.FindDeepestPoolAt(snapshot, baseToken, quoteToken)
+	if err != nil {
+		return models.TickerInfo{}, err
+	}
+
+	return h.poolTickerInfo(deepest.Pool, deepest.SqrtPriceX96, snapshot.Number())
 }
 
 // Returns an instance for interacting with the IUniswapV3Pool smart contract
@@ -119,13 +249,8 @@ func (h *UniswapV3Handler) getPoolInstance(address string) (*uniswapV3Pool.Unisw
 	return instance, nil
 }
 
-// Returns the current mid price of a pool
-func (h *UniswapV3Handler) getPoolPrice(pool *PoolWrapper) (models.TickerInfo, error) {
-	instance, err := h.getPoolInstance(pool.PoolAddress)
-	if err != nil {
-		panic(err)
-	}
-
+// Returns the mid price of a pool from its sqrt price
+func (h *UniswapV3Handler) poolTickerInfo(pool *PoolWrapper, sqrtPriceX96 *big.Int, blockNumber uint64) (models.TickerInfo, error) {
 	token0, err := ethHandler.GetToken(pool.ChainId, pool.Token0Symbol)
 	if err != nil {
 		panic(err)
@@ -136,16 +261,29 @@ func (h *UniswapV3Handler) getPoolPrice(pool *PoolWrapper) (models.TickerInfo, e
 		panic(err)
 	}
 
-	poolState, err := instance.Slot0(&bind.CallOpts{})
-	if err != nil {
-		panic(err)
+	token0Price := sqrtPriceToTokenPrice(sqrtPriceX96, token0, token1)
+
+	result := models.TickerInfo{
+		Symbol:         pool.Symbol(),
+		Base:           string(token0.AssetId()),
+		Quote:          string(token1.AssetId()),
+		Price:          token0Price.FloatString(int(token1.Decimals)),
+		MakerComission: pool.FeeString(),
+		TakerComission: pool.FeeString(),
+		Timestamp:      time.Now(),
+		BlockNumber:    blockNumber,
 	}
 
+	return result, nil
+}
+
+// Returns the price of token0 in token1 units from the sqrt price of a pool
+func sqrtPriceToTokenPrice(sqrtPriceX96 *big.Int, token0 *ethHandler.Token, token1 *ethHandler.Token) *big.Rat {
 	// https://docs.uniswap.org/sdk/guides/fetching-prices
 	// Convert SqrtPriceX96 to token0 price using the formula below:
 	// price = SqrtPriceX96 ** 2 / 2 ** 192
 	priceX96 := new(big.Int)
-	priceX96.Mul(poolState.SqrtPriceX96, poolState.SqrtPriceX96)
+	priceX96.Mul(sqrtPriceX96, sqrtPriceX96)
 	token0Price := new(big.Rat).SetFrac(priceX96, q192)
 
 	// Determine the priceScalar based on the number of decimals in the
@@ -164,21 +302,103 @@ func (h *UniswapV3Handler) getPoolPrice(pool *PoolWrapper) (models.TickerInfo, e
 		token0Price.Quo(token0Price, priceScalar)
 	}
 
-	result := models.TickerInfo{
-		Symbol:         pool.Symbol(),
-		Base:           pool.Token0Symbol,
-		Quote:          pool.Token1Symbol,
-		Price:          token0Price.FloatString(int(token1.Decimals)),
-		MakerComission: pool.FeeString(),
-		TakerComission: pool.FeeString(),
-		Timestamp:      time.Now(),
-	}
-
-	return result, nil
+	return token0Price
 }
 
 func (h *UniswapV3Handler) ExecuteOrder(order models.Order) error {
-	fmt.Printf("Executing %v/%v %v order\n", order.Base, order.Quote, order.Action.String())
+	wallet, err := ethHandler.GetWallet(os.Getenv("WALLET_PRIVATE_KEY"))
+	if err != nil {
+		panic(err)
+	}
+
+	baseToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, order.Base)
+	if err != nil {
+		panic(err)
+	}
+
+	quoteToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, order.Quote)
+	if err != nil {
+		panic(err)
+	}
+
+	wethToken, err := ethHandler.GetTokenForAsset(h.Network.ChainId, h.Network.NativeCurrency.Symbol)
+	if err != nil {
+		panic(err)
+	}
+
+	var tokenIn, tokenOut *ethHandler.Token
+	switch order.Action {
+	case models.BuyLongSpot:
+		tokenIn, tokenOut = quoteToken, baseToken
+	case models.SellLongSpot:
+		tokenIn, tokenOut = baseToken, quoteToken
+	default:
+		panic(fmt.Errorf("unsupported action %v", order.Action))
+	}
+
+	amount, amountLimit := order.LiqPoolAmountIn, order.LiqPoolAmountOut
+	if order.SwapKind == models.ExactOutput {
+		amount, amountLimit = order.LiqPoolAmountOut, order.LiqPoolAmountIn
+	}
+
+	route, err := h.FindBestPools(tokenIn, tokenOut, amount, order.SwapKind)
+	if err != nil {
+		panic(err)
+	}
+
+	// Chains without QuoterV2 skip the verification
+	if h.VerifyQuotes && HasQuoterV2(h.Network.ChainId) {
+		err := h.VerifyRoute(route)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if h.TwapWindow > 0 {
+		for _, allocation := range route.Allocations {
+			err := h.CheckSpotPrice(allocation.Pool.Pool, h.TwapWindow, h.MaxTwapDeviationBps)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	wethAddress := wethToken.AddressForGeth()
+	deadline := time.Now().Add(order.Deadline)
+	swaps := route.SwapParams(amountLimit)
+	for _, params := range swaps {
+		params.Recipient = wallet.Address
+		params.Deadline = deadline
+		params.NativeIn = h.SwapNativeETH && route.TokenIn == wethAddress
+		params.NativeOut = h.SwapNativeETH && route.TokenOut == wethAddress
+	}
+
+	tx, err := h.ExecuteSwaps(wallet, swaps)
+	if err != nil {
+		panic(err)
+	}
+
+	if tx == nil {
+		panic("failed to prepare transaction")
+	}
+
+	fmt.Printf("\n[[ %v/%v %v %v tx ]]\n", order.Base, order.Quote, order.Action.String(), order.SwapKind.String())
+	fmt.Printf("router: %v\n", h.RouterVersion)
+	fmt.Printf("pools: %v\n", route)
+	fmt.Printf("tx hash: %s\n", tx.Hash())
+	fmt.Printf("gas priority fee: %v\n", tx.GasTipCap())
+	fmt.Printf("gas max fee: %v\n", tx.GasFeeCap())
+	fmt.Printf("gas limit: %v\n", tx.Gas())
+	if !h.SendSwapTx {
+		fmt.Println("Note: transaction not sent on blockchain")
+		return nil
+	}
+
+	_, err = h.WaitTxMined(tx, wallet.Address, txMineWaitTimeout)
+	if err != nil {
+		panic(err)
+	}
+
 	return nil
 }
 
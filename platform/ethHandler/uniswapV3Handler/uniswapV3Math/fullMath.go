@@ -0,0 +1,66 @@
+package uniswapV3Math
+
+import (
+	"errors"
+	"math/big"
+
+	gethMath "github.com/ethereum/go-ethereum/common/math"
+)
+
+var (
+	Q96  = gethMath.BigPow(2, 96)
+	Q128 = gethMath.BigPow(2, 128)
+
+	MaxUint160 = new(big.Int).Sub(gethMath.BigPow(2, 160), big.NewInt(1))
+
+	ErrOverflow       = errors.New("uint256 overflow")
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+// Returns floor(a * b / denominator) like FullMath.mulDiv, which reverts if the result overflows a uint256
+func MulDiv(a *big.Int, b *big.Int, denominator *big.Int) (*big.Int, error) {
+	if denominator.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	result := new(big.Int).Mul(a, b)
+	result.Quo(result, denominator)
+	if result.Cmp(gethMath.MaxBig256) > 0 {
+		return nil, ErrOverflow
+	}
+
+	return result, nil
+}
+
+// Returns ceil(a * b / denominator) like FullMath.mulDivRoundingUp
+func MulDivRoundingUp(a *big.Int, b *big.Int, denominator *big.Int) (*big.Int, error) {
+	if denominator.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	product := new(big.Int).Mul(a, b)
+	result, remainder := new(big.Int).QuoRem(product, denominator, new(big.Int))
+	if remainder.Sign() > 0 {
+		result.Add(result, big.NewInt(1))
+	}
+
+	if result.Cmp(gethMath.MaxBig256) > 0 {
+		return nil, ErrOverflow
+	}
+
+	return result, nil
+}
+
+// Returns ceil(x / y) like UnsafeMath.divRoundingUp
+func DivRoundingUp(x *big.Int, y *big.Int) (*big.Int, error) {
+	if y.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	result, remainder := new(big.Int).QuoRem(x, y, new(big.Int))
+	if remainder.Sign() > 0 {
+		result.Add(result, big.NewInt(1))
+	}
+
+	return result, nil
+}
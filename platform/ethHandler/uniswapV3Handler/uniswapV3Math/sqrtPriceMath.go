@@ -0,0 +1,181 @@
+package uniswapV3Math
+
+import (
+	"errors"
+	"math/big"
+
+	gethMath "github.com/ethereum/go-ethereum/common/math"
+)
+
+var (
+	ErrInvalidPrice     = errors.New("SqrtPriceMath: invalid price")
+	ErrInvalidLiquidity = errors.New("SqrtPriceMath: invalid liquidity")
+	ErrPriceOverflow    = errors.New("SqrtPriceMath: price overflow")
+)
+
+// Returns the next sqrt price from an amount of token0, rounded up.
+// Identical to SqrtPriceMath.getNextSqrtPriceFromAmount0RoundingUp, including the
+// less precise formula it falls back to when the product overflows a uint256.
+func getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96 *big.Int, liquidity *big.Int, amount *big.Int, add bool) (*big.Int, error) {
+	if amount.Sign() == 0 {
+		return new(big.Int).Set(sqrtPX96), nil
+	}
+
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	product := new(big.Int).Mul(amount, sqrtPX96)
+
+	if add {
+		if product.Cmp(gethMath.MaxBig256) <= 0 {
+			denominator := new(big.Int).Add(numerator1, product)
+			if denominator.Cmp(gethMath.MaxBig256) <= 0 {
+				return MulDivRoundingUp(numerator1, sqrtPX96, denominator)
+			}
+		}
+
+		// numerator1 / (numerator1 / sqrtPX96 + amount), rounded up
+		denominator := new(big.Int).Quo(numerator1, sqrtPX96)
+		denominator.Add(denominator, amount)
+		if denominator.Cmp(gethMath.MaxBig256) > 0 {
+			return nil, ErrOverflow
+		}
+
+		return DivRoundingUp(numerator1, denominator)
+	}
+
+	if product.Cmp(gethMath.MaxBig256) > 0 || numerator1.Cmp(product) <= 0 {
+		return nil, ErrPriceOverflow
+	}
+
+	denominator := new(big.Int).Sub(numerator1, product)
+	result, err := MulDivRoundingUp(numerator1, sqrtPX96, denominator)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Cmp(MaxUint160) > 0 {
+		return nil, ErrPriceOverflow
+	}
+
+	return result, nil
+}
+
+// Returns the next sqrt price from an amount of token1, rounded down.
+// Identical to SqrtPriceMath.getNextSqrtPriceFromAmount1RoundingDown.
+func getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96 *big.Int, liquidity *big.Int, amount *big.Int, add bool) (*big.Int, error) {
+	var quotient *big.Int
+	var err error
+
+	if add {
+		if amount.Cmp(MaxUint160) <= 0 {
+			quotient = new(big.Int).Lsh(amount, 96)
+			quotient.Quo(quotient, liquidity)
+		} else {
+			quotient, err = MulDiv(amount, Q96, liquidity)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result := quotient.Add(quotient, sqrtPX96)
+		if result.Cmp(MaxUint160) > 0 {
+			return nil, ErrPriceOverflow
+		}
+
+		return result, nil
+	}
+
+	if amount.Cmp(MaxUint160) <= 0 {
+		quotient, err = DivRoundingUp(new(big.Int).Lsh(amount, 96), liquidity)
+	} else {
+		quotient, err = MulDivRoundingUp(amount, Q96, liquidity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if sqrtPX96.Cmp(quotient) <= 0 {
+		return nil, ErrPriceOverflow
+	}
+
+	return new(big.Int).Sub(sqrtPX96, quotient), nil
+}
+
+// Returns the sqrt price after adding an input amount of token0 or token1
+func GetNextSqrtPriceFromInput(sqrtPX96 *big.Int, liquidity *big.Int, amountIn *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+
+	if liquidity.Sign() <= 0 {
+		return nil, ErrInvalidLiquidity
+	}
+
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountIn, true)
+	}
+
+	return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountIn, true)
+}
+
+// Returns the sqrt price after removing an output amount of token0 or token1
+func GetNextSqrtPriceFromOutput(sqrtPX96 *big.Int, liquidity *big.Int, amountOut *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+
+	if liquidity.Sign() <= 0 {
+		return nil, ErrInvalidLiquidity
+	}
+
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountOut, false)
+	}
+
+	return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountOut, false)
+}
+
+// Returns the amount of token0 between two prices for a liquidity:
+// liquidity / sqrt(lower) - liquidity / sqrt(upper)
+func GetAmount0Delta(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	if sqrtRatioAX96.Cmp(sqrtRatioBX96) > 0 {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+
+	if sqrtRatioAX96.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	numerator2 := new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+
+	if roundUp {
+		result, err := MulDivRoundingUp(numerator1, numerator2, sqrtRatioBX96)
+		if err != nil {
+			return nil, err
+		}
+
+		return DivRoundingUp(result, sqrtRatioAX96)
+	}
+
+	result, err := MulDiv(numerator1, numerator2, sqrtRatioBX96)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Quo(result, sqrtRatioAX96), nil
+}
+
+// Returns the amount of token1 between two prices for a liquidity:
+// liquidity * (sqrt(upper) - sqrt(lower))
+func GetAmount1Delta(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	if sqrtRatioAX96.Cmp(sqrtRatioBX96) > 0 {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+
+	difference := new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+	if roundUp {
+		return MulDivRoundingUp(liquidity, difference, Q96)
+	}
+
+	return MulDiv(liquidity, difference, Q96)
+}
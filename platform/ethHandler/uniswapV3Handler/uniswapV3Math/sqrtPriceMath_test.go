@@ -0,0 +1,159 @@
+package uniswapV3Math
+
+import (
+	"math/big"
+	"testing"
+
+	gethMath "github.com/ethereum/go-ethereum/common/math"
+)
+
+// Vectors from the SqrtPriceMath tests of Uniswap v3-core
+
+var (
+	e17 = bigInt("100000000000000000")
+	e18 = bigInt("1000000000000000000")
+
+	// Price at which a liquidity of 1024 holds 4 token0 and 262144 token1
+	echidnaPrice = bigInt("20282409603651670423947251286016")
+)
+
+// Returns sqrt(reserve1 / reserve0) as a Q64.96, rounded down like encodePriceSqrt
+func encodePriceSqrt(reserve1 int64, reserve0 int64) *big.Int {
+	ratio := new(big.Int).Lsh(big.NewInt(reserve1), 192)
+	ratio.Quo(ratio, big.NewInt(reserve0))
+	return ratio.Sqrt(ratio)
+}
+
+func TestGetNextSqrtPriceFromInput(t *testing.T) {
+	maxUint128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	// The largest input whose product with the price does not overflow
+	maxAmountNoOverflow := new(big.Int).Lsh(maxUint128, 96)
+	maxAmountNoOverflow.Quo(maxAmountNoOverflow, MaxUint160)
+	maxAmountNoOverflow.Sub(gethMath.MaxBig256, maxAmountNoOverflow)
+
+	tests := []struct {
+		name       string
+		sqrtPrice  *big.Int
+		liquidity  *big.Int
+		amountIn   *big.Int
+		zeroForOne bool
+		want       *big.Int // nil if the call reverts
+	}{
+		{"price is zero", big.NewInt(0), big.NewInt(1), e17, false, nil},
+		{"liquidity is zero", big.NewInt(1), big.NewInt(0), e17, true, nil},
+		{"input overflows the price", MaxUint160, big.NewInt(1024), big.NewInt(1024), false, nil},
+		{"input cannot underflow the price", big.NewInt(1), big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), 255), true, big.NewInt(1)},
+		{"zero input, zeroForOne", encodePriceSqrt(1, 1), e17, big.NewInt(0), true, encodePriceSqrt(1, 1)},
+		{"zero input, oneForZero", encodePriceSqrt(1, 1), e17, big.NewInt(0), false, encodePriceSqrt(1, 1)},
+		{"minimum price for max inputs", MaxUint160, maxUint128, maxAmountNoOverflow, true, big.NewInt(1)},
+		{"0.1 token1", encodePriceSqrt(1, 1), e18, e17, false, bigInt("87150978765690771352898345369")},
+		{"0.1 token0", encodePriceSqrt(1, 1), e18, e17, true, bigInt("72025602285694852357767227579")},
+		{"input above uint96, zeroForOne", encodePriceSqrt(1, 1), bigInt("10000000000000000000"), new(big.Int).Lsh(big.NewInt(1), 100), true, bigInt("624999999995069620")},
+		// The quotient of inputs above uint160 is rounded down through mulDiv
+		{"input above uint160, oneForZero", encodePriceSqrt(1, 1), bigInt("340282366920938463463374607431768211455"), new(big.Int).Lsh(big.NewInt(1), 160), false, bigInt("340282367000166625977638945025312161793")},
+		{"returns 1 with enough input", encodePriceSqrt(1, 1), big.NewInt(1), new(big.Int).Rsh(gethMath.MaxBig256, 1), true, big.NewInt(1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GetNextSqrtPriceFromInput(test.sqrtPrice, test.liquidity, test.amountIn, test.zeroForOne)
+			assertRevertOrEqual(t, got, err, test.want)
+		})
+	}
+}
+
+func TestGetNextSqrtPriceFromOutput(t *testing.T) {
+	tests := []struct {
+		name       string
+		sqrtPrice  *big.Int
+		liquidity  *big.Int
+		amountOut  *big.Int
+		zeroForOne bool
+		want       *big.Int // nil if the call reverts
+	}{
+		{"price is zero", big.NewInt(0), big.NewInt(1), e17, false, nil},
+		{"liquidity is zero", big.NewInt(1), big.NewInt(0), e17, true, nil},
+		{"output is the virtual reserves of token0", echidnaPrice, big.NewInt(1024), big.NewInt(4), false, nil},
+		{"output above the virtual reserves of token0", echidnaPrice, big.NewInt(1024), big.NewInt(5), false, nil},
+		{"output above the virtual reserves of token1", echidnaPrice, big.NewInt(1024), big.NewInt(262145), true, nil},
+		{"output is the virtual reserves of token1", echidnaPrice, big.NewInt(1024), big.NewInt(262144), true, nil},
+		{"output just below the virtual reserves of token1", echidnaPrice, big.NewInt(1024), big.NewInt(262143), true, bigInt("77371252455336267181195264")},
+		{"zero output, zeroForOne", encodePriceSqrt(1, 1), e17, big.NewInt(0), true, encodePriceSqrt(1, 1)},
+		{"zero output, oneForZero", encodePriceSqrt(1, 1), e17, big.NewInt(0), false, encodePriceSqrt(1, 1)},
+		{"0.1 token1", encodePriceSqrt(1, 1), e18, e17, false, bigInt("88031291682515930659493278152")},
+		{"0.1 token0", encodePriceSqrt(1, 1), e18, e17, true, bigInt("71305346262837903834189555302")},
+		{"impossible output, zeroForOne", encodePriceSqrt(1, 1), big.NewInt(1), gethMath.MaxBig256, true, nil},
+		{"impossible output, oneForZero", encodePriceSqrt(1, 1), big.NewInt(1), gethMath.MaxBig256, false, nil},
+		// The quotient of outputs above uint160 is rounded up through mulDivRoundingUp
+		{"output above uint160", MaxUint160, bigInt("340282366920938463463374607431768211455"), new(big.Int).Lsh(big.NewInt(1), 160), true, bigInt("1461501636990620551282746369252908412224164331517")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GetNextSqrtPriceFromOutput(test.sqrtPrice, test.liquidity, test.amountOut, test.zeroForOne)
+			assertRevertOrEqual(t, got, err, test.want)
+		})
+	}
+}
+
+func TestGetAmountDeltas(t *testing.T) {
+	price121 := encodePriceSqrt(121, 100)
+	tests := []struct {
+		name     string
+		delta    func(*big.Int, *big.Int, *big.Int, bool) (*big.Int, error)
+		sqrtA    *big.Int
+		sqrtB    *big.Int
+		liq      *big.Int
+		wantUp   *big.Int
+		wantDown *big.Int
+	}{
+		{"amount0 of zero liquidity", GetAmount0Delta, Q96, encodePriceSqrt(2, 1), big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+		{"amount0 of equal prices", GetAmount0Delta, Q96, Q96, e18, big.NewInt(0), big.NewInt(0)},
+		{"amount0 from 1 to 1.21", GetAmount0Delta, Q96, price121, e18, bigInt("90909090909090910"), bigInt("90909090909090909")},
+		{"amount0 of reversed prices", GetAmount0Delta, price121, Q96, e18, bigInt("90909090909090910"), bigInt("90909090909090909")},
+		{"amount1 of zero liquidity", GetAmount1Delta, Q96, encodePriceSqrt(2, 1), big.NewInt(0), big.NewInt(0), big.NewInt(0)},
+		{"amount1 of equal prices", GetAmount1Delta, Q96, Q96, e18, big.NewInt(0), big.NewInt(0)},
+		{"amount1 from 1 to 1.21", GetAmount1Delta, Q96, price121, e18, bigInt("100000000000000000"), bigInt("99999999999999999")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			up, err := test.delta(test.sqrtA, test.sqrtB, test.liq, true)
+			assertRevertOrEqual(t, up, err, test.wantUp)
+
+			down, err := test.delta(test.sqrtA, test.sqrtB, test.liq, false)
+			assertRevertOrEqual(t, down, err, test.wantDown)
+		})
+	}
+}
+
+// The product of the prices overflows a uint256 in getAmount0Delta
+func TestGetAmount0DeltaOverflow(t *testing.T) {
+	sqrtP := bigInt("1025574284609383690408304870162715216695788925244")
+	liquidity := bigInt("50015962439936049619261659728067971248")
+
+	sqrtQ, err := GetNextSqrtPriceFromInput(sqrtP, liquidity, big.NewInt(406), true)
+	assertRevertOrEqual(t, sqrtQ, err, bigInt("1025574284609383582644711336373707553698163132913"))
+
+	amount0, err := GetAmount0Delta(sqrtQ, sqrtP, liquidity, true)
+	assertRevertOrEqual(t, amount0, err, big.NewInt(406))
+}
+
+func assertRevertOrEqual(t *testing.T, got *big.Int, err error, want *big.Int) {
+	t.Helper()
+
+	if want == nil {
+		if err == nil {
+			t.Errorf("got %v, want a revert", got)
+		}
+		return
+	}
+
+	if err != nil {
+		t.Fatalf("unexpected revert: %v", err)
+	}
+
+	if got.Cmp(want) != 0 {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
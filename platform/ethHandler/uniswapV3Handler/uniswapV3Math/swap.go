@@ -0,0 +1,188 @@
+package uniswapV3Math
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrAmountSpecifiedZero = errors.New("UniswapV3Pool: amount specified is zero")
+	ErrInvalidPriceLimit   = errors.New("UniswapV3Pool: invalid sqrt price limit")
+	ErrLiquidityUnderflow  = errors.New("UniswapV3Pool: liquidity underflow")
+)
+
+// State of a pool needed to simulate a swap
+type PoolState struct {
+	SqrtPriceX96 *big.Int
+	Tick         int
+	Liquidity    *big.Int
+	Fee          uint // in hundredths of a basis point
+	TickSpacing  int
+}
+
+// Result of a simulated swap.
+// Amounts are the deltas of the pool balances: positive amounts are received by the pool,
+// negative amounts are sent by the pool.
+type SwapResult struct {
+	Amount0      *big.Int
+	Amount1      *big.Int
+	SqrtPriceX96 *big.Int // price after the swap
+	Tick         int      // tick after the swap
+	Liquidity    *big.Int // in-range liquidity after the swap
+	TicksCrossed []int    // initialized ticks crossed by the swap, in order
+	FeeAmount    *big.Int // fee paid in the input token
+}
+
+// Returns the amount received by the pool
+func (r *SwapResult) AmountIn(zeroForOne bool) *big.Int {
+	if zeroForOne {
+		return r.Amount0
+	}
+
+	return r.Amount1
+}
+
+// Returns the amount sent by the pool
+func (r *SwapResult) AmountOut(zeroForOne bool) *big.Int {
+	if zeroForOne {
+		return new(big.Int).Neg(r.Amount1)
+	}
+
+	return new(big.Int).Neg(r.Amount0)
+}
+
+// Simulates UniswapV3Pool.swap across the initialized ticks of a pool.
+// A positive amountSpecified is an exact input and a negative amountSpecified an exact output.
+// A nil sqrtPriceLimitX96 lets the swap move the price up to the bounds of the pool.
+// Protocol fees are not deducted, they do not change the amounts of the swap.
+func Swap(
+	pool *PoolState,
+	provider TickDataProvider,
+	zeroForOne bool,
+	amountSpecified *big.Int,
+	sqrtPriceLimitX96 *big.Int,
+) (*SwapResult, error) {
+	if amountSpecified.Sign() == 0 {
+		return nil, ErrAmountSpecifiedZero
+	}
+
+	if sqrtPriceLimitX96 == nil {
+		if zeroForOne {
+			sqrtPriceLimitX96 = new(big.Int).Add(MinSqrtRatio, big.NewInt(1))
+		} else {
+			sqrtPriceLimitX96 = new(big.Int).Sub(MaxSqrtRatio, big.NewInt(1))
+		}
+	}
+
+	if zeroForOne {
+		if sqrtPriceLimitX96.Cmp(pool.SqrtPriceX96) >= 0 || sqrtPriceLimitX96.Cmp(MinSqrtRatio) <= 0 {
+			return nil, ErrInvalidPriceLimit
+		}
+	} else {
+		if sqrtPriceLimitX96.Cmp(pool.SqrtPriceX96) <= 0 || sqrtPriceLimitX96.Cmp(MaxSqrtRatio) >= 0 {
+			return nil, ErrInvalidPriceLimit
+		}
+	}
+
+	exactInput := amountSpecified.Sign() > 0
+	amountSpecifiedRemaining := new(big.Int).Set(amountSpecified)
+	amountCalculated := new(big.Int)
+	sqrtPriceX96 := new(big.Int).Set(pool.SqrtPriceX96)
+	tick := pool.Tick
+	liquidity := new(big.Int).Set(pool.Liquidity)
+	feeAmount := new(big.Int)
+	var ticksCrossed []int
+
+	for amountSpecifiedRemaining.Sign() != 0 && sqrtPriceX96.Cmp(sqrtPriceLimitX96) != 0 {
+		sqrtPriceStartX96 := sqrtPriceX96
+
+		tickNext, initialized, err := NextInitializedTickWithinOneWord(provider, tick, pool.TickSpacing, zeroForOne)
+		if err != nil {
+			return nil, err
+		}
+
+		// The tick bitmap is not aware of the tick bounds
+		if tickNext < MinTick {
+			tickNext = MinTick
+		} else if tickNext > MaxTick {
+			tickNext = MaxTick
+		}
+
+		sqrtPriceNextX96, err := GetSqrtRatioAtTick(tickNext)
+		if err != nil {
+			return nil, err
+		}
+
+		sqrtPriceTargetX96 := sqrtPriceNextX96
+		if (zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) < 0) ||
+			(!zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) > 0) {
+			sqrtPriceTargetX96 = sqrtPriceLimitX96
+		}
+
+		step, err := ComputeSwapStep(sqrtPriceX96, sqrtPriceTargetX96, liquidity, amountSpecifiedRemaining, pool.Fee)
+		if err != nil {
+			return nil, err
+		}
+		sqrtPriceX96 = step.SqrtRatioNextX96
+		feeAmount.Add(feeAmount, step.FeeAmount)
+
+		if exactInput {
+			amountSpecifiedRemaining.Sub(amountSpecifiedRemaining, step.AmountIn)
+			amountSpecifiedRemaining.Sub(amountSpecifiedRemaining, step.FeeAmount)
+			amountCalculated.Sub(amountCalculated, step.AmountOut)
+		} else {
+			amountSpecifiedRemaining.Add(amountSpecifiedRemaining, step.AmountOut)
+			amountCalculated.Add(amountCalculated, step.AmountIn)
+			amountCalculated.Add(amountCalculated, step.FeeAmount)
+		}
+
+		if sqrtPriceX96.Cmp(sqrtPriceNextX96) == 0 {
+			// Shift the tick when the next price is reached, crossing it if it is initialized
+			if initialized {
+				liquidityNet, err := provider.LiquidityNet(tickNext)
+				if err != nil {
+					return nil, err
+				}
+
+				// Moving leftward, liquidityNet is interpreted as the opposite sign
+				if zeroForOne {
+					liquidityNet = new(big.Int).Neg(liquidityNet)
+				}
+
+				liquidity.Add(liquidity, liquidityNet)
+				if liquidity.Sign() < 0 {
+					return nil, ErrLiquidityUnderflow
+				}
+				ticksCrossed = append(ticksCrossed, tickNext)
+			}
+
+			tick = tickNext
+			if zeroForOne {
+				tick = tickNext - 1
+			}
+		} else if sqrtPriceX96.Cmp(sqrtPriceStartX96) != 0 {
+			// Recompute the tick unless the price did not move
+			tick, err = GetTickAtSqrtRatio(sqrtPriceX96)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &SwapResult{
+		SqrtPriceX96: sqrtPriceX96,
+		Tick:         tick,
+		Liquidity:    liquidity,
+		TicksCrossed: ticksCrossed,
+		FeeAmount:    feeAmount,
+	}
+
+	amountSpecifiedUsed := new(big.Int).Sub(amountSpecified, amountSpecifiedRemaining)
+	if zeroForOne == exactInput {
+		result.Amount0, result.Amount1 = amountSpecifiedUsed, amountCalculated
+	} else {
+		result.Amount0, result.Amount1 = amountCalculated, amountSpecifiedUsed
+	}
+
+	return result, nil
+}
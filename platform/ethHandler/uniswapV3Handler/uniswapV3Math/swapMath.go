@@ -0,0 +1,126 @@
+package uniswapV3Math
+
+import "math/big"
+
+const FeeDenominator = 1000000 // pool fees are in hundredths of a basis point
+
+// Result of swapping within a single tick range
+type SwapStep struct {
+	SqrtRatioNextX96 *big.Int
+	AmountIn         *big.Int
+	AmountOut        *big.Int
+	FeeAmount        *big.Int
+}
+
+// Computes the result of swapping some amount in, or amount out, given the parameters of the swap.
+// Identical to SwapMath.computeSwapStep: a positive amountRemaining is an exact input and
+// a negative amountRemaining an exact output. The fee is in hundredths of a basis point.
+func ComputeSwapStep(
+	sqrtRatioCurrentX96 *big.Int,
+	sqrtRatioTargetX96 *big.Int,
+	liquidity *big.Int,
+	amountRemaining *big.Int,
+	feePips uint,
+) (*SwapStep, error) {
+	zeroForOne := sqrtRatioCurrentX96.Cmp(sqrtRatioTargetX96) >= 0
+	exactIn := amountRemaining.Sign() >= 0
+	feeDenominator := big.NewInt(FeeDenominator)
+	feeComplement := big.NewInt(int64(FeeDenominator - feePips))
+
+	step := &SwapStep{}
+	var err error
+
+	if exactIn {
+		amountRemainingLessFee, err := MulDiv(amountRemaining, feeComplement, feeDenominator)
+		if err != nil {
+			return nil, err
+		}
+
+		if zeroForOne {
+			step.AmountIn, err = GetAmount0Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, true)
+		} else {
+			step.AmountIn, err = GetAmount1Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, true)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if amountRemainingLessFee.Cmp(step.AmountIn) >= 0 {
+			step.SqrtRatioNextX96 = new(big.Int).Set(sqrtRatioTargetX96)
+		} else {
+			step.SqrtRatioNextX96, err = GetNextSqrtPriceFromInput(sqrtRatioCurrentX96, liquidity, amountRemainingLessFee, zeroForOne)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if zeroForOne {
+			step.AmountOut, err = GetAmount1Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, false)
+		} else {
+			step.AmountOut, err = GetAmount0Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		amountRemainingOut := new(big.Int).Neg(amountRemaining)
+		if amountRemainingOut.Cmp(step.AmountOut) >= 0 {
+			step.SqrtRatioNextX96 = new(big.Int).Set(sqrtRatioTargetX96)
+		} else {
+			step.SqrtRatioNextX96, err = GetNextSqrtPriceFromOutput(sqrtRatioCurrentX96, liquidity, amountRemainingOut, zeroForOne)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	max := sqrtRatioTargetX96.Cmp(step.SqrtRatioNextX96) == 0
+
+	// Get the input/output amounts
+	if zeroForOne {
+		if !max || !exactIn {
+			step.AmountIn, err = GetAmount0Delta(step.SqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !max || exactIn {
+			step.AmountOut, err = GetAmount1Delta(step.SqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, false)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if !max || !exactIn {
+			step.AmountIn, err = GetAmount1Delta(sqrtRatioCurrentX96, step.SqrtRatioNextX96, liquidity, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !max || exactIn {
+			step.AmountOut, err = GetAmount0Delta(sqrtRatioCurrentX96, step.SqrtRatioNextX96, liquidity, false)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Cap the output amount to not exceed the remaining output amount
+	if !exactIn && step.AmountOut.Cmp(new(big.Int).Neg(amountRemaining)) > 0 {
+		step.AmountOut = new(big.Int).Neg(amountRemaining)
+	}
+
+	if exactIn && step.SqrtRatioNextX96.Cmp(sqrtRatioTargetX96) != 0 {
+		// The target was not reached, the remainder of the input is taken as the fee
+		step.FeeAmount = new(big.Int).Sub(amountRemaining, step.AmountIn)
+	} else {
+		step.FeeAmount, err = MulDivRoundingUp(step.AmountIn, big.NewInt(int64(feePips)), feeComplement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return step, nil
+}
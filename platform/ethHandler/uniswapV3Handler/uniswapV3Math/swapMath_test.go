@@ -0,0 +1,188 @@
+package uniswapV3Math
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Vectors from the SwapMath tests of Uniswap v3-core
+
+func TestComputeSwapStep(t *testing.T) {
+	price := encodePriceSqrt(1, 1)
+	amountInLessFee := new(big.Int).Sub(e18, big.NewInt(600000000000000))
+	priceAfterInputLessFee, err := GetNextSqrtPriceFromInput(price, new(big.Int).Mul(e18, big.NewInt(2)), amountInLessFee, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priceAfterOutput, err := GetNextSqrtPriceFromOutput(price, new(big.Int).Mul(e18, big.NewInt(2)), e18, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		sqrtPrice       *big.Int
+		sqrtPriceTarget *big.Int
+		liquidity       *big.Int
+		amountRemaining *big.Int
+		feePips         uint
+		want            SwapStep
+	}{
+		{
+			name:            "exact input capped at the price target, one for zero",
+			sqrtPrice:       price,
+			sqrtPriceTarget: encodePriceSqrt(101, 100),
+			liquidity:       bigInt("2000000000000000000"),
+			amountRemaining: e18,
+			feePips:         600,
+			want: SwapStep{
+				SqrtRatioNextX96: encodePriceSqrt(101, 100),
+				AmountIn:         bigInt("9975124224178055"),
+				AmountOut:        bigInt("9925619580021728"),
+				FeeAmount:        bigInt("5988667735148"),
+			},
+		},
+		{
+			name:            "exact output capped at the price target, one for zero",
+			sqrtPrice:       price,
+			sqrtPriceTarget: encodePriceSqrt(101, 100),
+			liquidity:       bigInt("2000000000000000000"),
+			amountRemaining: new(big.Int).Neg(e18),
+			feePips:         600,
+			want: SwapStep{
+				SqrtRatioNextX96: encodePriceSqrt(101, 100),
+				AmountIn:         bigInt("9975124224178055"),
+				AmountOut:        bigInt("9925619580021728"),
+				FeeAmount:        bigInt("5988667735148"),
+			},
+		},
+		{
+			name:            "exact input fully spent, one for zero",
+			sqrtPrice:       price,
+			sqrtPriceTarget: encodePriceSqrt(1000, 100),
+			liquidity:       bigInt("2000000000000000000"),
+			amountRemaining: e18,
+			feePips:         600,
+			want: SwapStep{
+				SqrtRatioNextX96: priceAfterInputLessFee,
+				AmountIn:         bigInt("999400000000000000"),
+				AmountOut:        bigInt("666399946655997866"),
+				FeeAmount:        bigInt("600000000000000"),
+			},
+		},
+		{
+			name:            "exact output fully received, one for zero",
+			sqrtPrice:       price,
+			sqrtPriceTarget: encodePriceSqrt(10000, 100),
+			liquidity:       bigInt("2000000000000000000"),
+			amountRemaining: new(big.Int).Neg(e18),
+			feePips:         600,
+			want: SwapStep{
+				SqrtRatioNextX96: priceAfterOutput,
+				AmountIn:         bigInt("2000000000000000000"),
+				AmountOut:        e18,
+				FeeAmount:        bigInt("1200720432259356"),
+			},
+		},
+		{
+			name:            "output capped at the desired output",
+			sqrtPrice:       bigInt("417332158212080721273783715441582"),
+			sqrtPriceTarget: bigInt("1452870262520218020823638996"),
+			liquidity:       bigInt("159344665391607089467575320103"),
+			amountRemaining: big.NewInt(-1),
+			feePips:         1,
+			want: SwapStep{
+				SqrtRatioNextX96: bigInt("417332158212080721273783715441581"),
+				AmountIn:         big.NewInt(1),
+				AmountOut:        big.NewInt(1),
+				FeeAmount:        big.NewInt(1),
+			},
+		},
+		{
+			name:            "target price of 1 uses partial input",
+			sqrtPrice:       big.NewInt(2),
+			sqrtPriceTarget: big.NewInt(1),
+			liquidity:       big.NewInt(1),
+			amountRemaining: bigInt("3915081100057732413702495386755767"),
+			feePips:         1,
+			want: SwapStep{
+				SqrtRatioNextX96: big.NewInt(1),
+				AmountIn:         bigInt("39614081257132168796771975168"),
+				AmountOut:        big.NewInt(0),
+				FeeAmount:        bigInt("39614120871253040049813"),
+			},
+		},
+		{
+			name:            "entire input taken as fee",
+			sqrtPrice:       big.NewInt(2413),
+			sqrtPriceTarget: bigInt("79887613182836312"),
+			liquidity:       bigInt("1985041575832132834610021537970"),
+			amountRemaining: big.NewInt(10),
+			feePips:         1872,
+			want: SwapStep{
+				SqrtRatioNextX96: big.NewInt(2413),
+				AmountIn:         big.NewInt(0),
+				AmountOut:        big.NewInt(0),
+				FeeAmount:        big.NewInt(10),
+			},
+		},
+		{
+			name:            "intermediate insufficient liquidity, zero for one exact output",
+			sqrtPrice:       echidnaPrice,
+			sqrtPriceTarget: new(big.Int).Quo(new(big.Int).Mul(echidnaPrice, big.NewInt(11)), big.NewInt(10)),
+			liquidity:       big.NewInt(1024),
+			amountRemaining: big.NewInt(-4),
+			feePips:         3000,
+			want: SwapStep{
+				SqrtRatioNextX96: new(big.Int).Quo(new(big.Int).Mul(echidnaPrice, big.NewInt(11)), big.NewInt(10)),
+				AmountIn:         big.NewInt(26215),
+				AmountOut:        big.NewInt(0),
+				FeeAmount:        big.NewInt(79),
+			},
+		},
+		{
+			name:            "intermediate insufficient liquidity, one for zero exact output",
+			sqrtPrice:       echidnaPrice,
+			sqrtPriceTarget: new(big.Int).Quo(new(big.Int).Mul(echidnaPrice, big.NewInt(9)), big.NewInt(10)),
+			liquidity:       big.NewInt(1024),
+			amountRemaining: big.NewInt(-263000),
+			feePips:         3000,
+			want: SwapStep{
+				SqrtRatioNextX96: new(big.Int).Quo(new(big.Int).Mul(echidnaPrice, big.NewInt(9)), big.NewInt(10)),
+				AmountIn:         big.NewInt(1),
+				AmountOut:        big.NewInt(26214),
+				FeeAmount:        big.NewInt(1),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ComputeSwapStep(test.sqrtPrice, test.sqrtPriceTarget, test.liquidity, test.amountRemaining, test.feePips)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertEqual(t, "sqrtRatioNext", got.SqrtRatioNextX96, test.want.SqrtRatioNextX96)
+			assertEqual(t, "amountIn", got.AmountIn, test.want.AmountIn)
+			assertEqual(t, "amountOut", got.AmountOut, test.want.AmountOut)
+			assertEqual(t, "feeAmount", got.FeeAmount, test.want.FeeAmount)
+
+			// An exact input step never spends more than the remaining input
+			if test.amountRemaining.Sign() > 0 {
+				spent := new(big.Int).Add(got.AmountIn, got.FeeAmount)
+				if spent.Cmp(test.amountRemaining) > 0 {
+					t.Errorf("spent %v of %v", spent, test.amountRemaining)
+				}
+			}
+		})
+	}
+}
+
+func assertEqual(t *testing.T, name string, got *big.Int, want *big.Int) {
+	t.Helper()
+
+	if got.Cmp(want) != 0 {
+		t.Errorf("%v: got %v, want %v", name, got, want)
+	}
+}
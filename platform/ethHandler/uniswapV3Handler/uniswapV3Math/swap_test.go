@@ -0,0 +1,180 @@
+package uniswapV3Math
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// Pool at price 1 with a 0.3% fee and two positions around the price:
+// 1e18 of liquidity in [-120, 120] and 2e18 in [-600, 600]
+func newTestPool() (*PoolState, *fakeTickProvider) {
+	pool := &PoolState{
+		SqrtPriceX96: new(big.Int).Set(Q96),
+		Tick:         0,
+		Liquidity:    bigInt("3000000000000000000"),
+		Fee:          3000,
+		TickSpacing:  60,
+	}
+
+	provider := &fakeTickProvider{
+		tickSpacing: 60,
+		liquidityNet: map[int]*big.Int{
+			-600: bigInt("2000000000000000000"),
+			-120: bigInt("1000000000000000000"),
+			120:  bigInt("-1000000000000000000"),
+			600:  bigInt("-2000000000000000000"),
+		},
+	}
+
+	return pool, provider
+}
+
+// Expected results computed with an independent implementation of UniswapV3Pool.swap
+func TestSwap(t *testing.T) {
+	sqrtPriceLimit, err := GetSqrtRatioAtTick(-300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		zeroForOne        bool
+		amountSpecified   *big.Int
+		sqrtPriceLimitX96 *big.Int
+		want              SwapResult
+	}{
+		{
+			name:            "exact input within the current range",
+			zeroForOne:      true,
+			amountSpecified: bigInt("10000000000000000"),
+			want: SwapResult{
+				Amount0:      bigInt("10000000000000000"),
+				Amount1:      bigInt("-9936976116041023"),
+				SqrtPriceX96: bigInt("78965733061390317106360479011"),
+				Tick:         -67,
+				Liquidity:    bigInt("3000000000000000000"),
+				FeeAmount:    bigInt("30000000000000"),
+			},
+		},
+		{
+			name:            "exact input crossing an initialized tick",
+			zeroForOne:      true,
+			amountSpecified: bigInt("50000000000000000"),
+			want: SwapResult{
+				Amount0:      bigInt("50000000000000000"),
+				Amount1:      bigInt("-48873971596049803"),
+				SqrtPriceX96: bigInt("77529026077803336642360650858"),
+				Tick:         -434,
+				Liquidity:    bigInt("2000000000000000000"),
+				TicksCrossed: []int{-120},
+				FeeAmount:    bigInt("150000000000001"),
+			},
+		},
+		{
+			name:            "exact output crossing an initialized tick",
+			zeroForOne:      false,
+			amountSpecified: bigInt("-50000000000000000"),
+			want: SwapResult{
+				Amount0:      bigInt("-50000000000000000"),
+				Amount1:      bigInt("51180143021868024"),
+				SqrtPriceX96: bigInt("81011147481342211946148482963"),
+				Tick:         445,
+				Liquidity:    bigInt("2000000000000000000"),
+				TicksCrossed: []int{120},
+				FeeAmount:    bigInt("153540429065605"),
+			},
+		},
+		{
+			name:            "exact input exhausting the liquidity",
+			zeroForOne:      true,
+			amountSpecified: bigInt("100000000000000000"),
+			want: SwapResult{
+				Amount0:      bigInt("67125086279482127"),
+				Amount1:      bigInt("-65087759518784001"),
+				SqrtPriceX96: new(big.Int).Add(MinSqrtRatio, big.NewInt(1)),
+				Tick:         MinTick,
+				Liquidity:    big.NewInt(0),
+				TicksCrossed: []int{-120, -600},
+				FeeAmount:    bigInt("201375258838447"),
+			},
+		},
+		{
+			name:              "exact input stopped by the price limit",
+			zeroForOne:        true,
+			amountSpecified:   bigInt("50000000000000000"),
+			sqrtPriceLimitX96: sqrtPriceLimit,
+			want: SwapResult{
+				Amount0:      bigInt("36351395118088085"),
+				Amount1:      bigInt("-35756380984424688"),
+				SqrtPriceX96: sqrtPriceLimit,
+				Tick:         -300,
+				Liquidity:    bigInt("2000000000000000000"),
+				TicksCrossed: []int{-120},
+				FeeAmount:    bigInt("109054185354265"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool, provider := newTestPool()
+
+			got, err := Swap(pool, provider, test.zeroForOne, test.amountSpecified, test.sqrtPriceLimitX96)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertEqual(t, "amount0", got.Amount0, test.want.Amount0)
+			assertEqual(t, "amount1", got.Amount1, test.want.Amount1)
+			assertEqual(t, "sqrtPrice", got.SqrtPriceX96, test.want.SqrtPriceX96)
+			assertEqual(t, "liquidity", got.Liquidity, test.want.Liquidity)
+			assertEqual(t, "feeAmount", got.FeeAmount, test.want.FeeAmount)
+			if got.Tick != test.want.Tick {
+				t.Errorf("tick: got %v, want %v", got.Tick, test.want.Tick)
+			}
+			if !reflect.DeepEqual(got.TicksCrossed, test.want.TicksCrossed) {
+				t.Errorf("ticks crossed: got %v, want %v", got.TicksCrossed, test.want.TicksCrossed)
+			}
+
+			// The pool state is not modified by the simulation
+			if pool.SqrtPriceX96.Cmp(Q96) != 0 || pool.Liquidity.Cmp(bigInt("3000000000000000000")) != 0 {
+				t.Error("the simulation modified the pool state")
+			}
+		})
+	}
+}
+
+func TestSwapErrors(t *testing.T) {
+	tests := []struct {
+		name              string
+		zeroForOne        bool
+		amountSpecified   *big.Int
+		sqrtPriceLimitX96 *big.Int
+		providerErr       error
+		want              error
+	}{
+		{name: "zero amount", zeroForOne: true, amountSpecified: big.NewInt(0), want: ErrAmountSpecifiedZero},
+		{name: "limit above the price", zeroForOne: true, amountSpecified: big.NewInt(1), sqrtPriceLimitX96: new(big.Int).Add(Q96, big.NewInt(1)), want: ErrInvalidPriceLimit},
+		{name: "limit below the price", zeroForOne: false, amountSpecified: big.NewInt(1), sqrtPriceLimitX96: new(big.Int).Sub(Q96, big.NewInt(1)), want: ErrInvalidPriceLimit},
+		{name: "limit at the min ratio", zeroForOne: true, amountSpecified: big.NewInt(1), sqrtPriceLimitX96: MinSqrtRatio, want: ErrInvalidPriceLimit},
+		{name: "provider error", zeroForOne: true, amountSpecified: big.NewInt(1), providerErr: errors.New("rpc failed")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool, provider := newTestPool()
+			provider.err = test.providerErr
+
+			want := test.want
+			if want == nil {
+				want = test.providerErr
+			}
+
+			if _, err := Swap(pool, provider, test.zeroForOne, test.amountSpecified, test.sqrtPriceLimitX96); !errors.Is(err, want) {
+				t.Errorf("got %v, want %v", err, want)
+			}
+		})
+	}
+}
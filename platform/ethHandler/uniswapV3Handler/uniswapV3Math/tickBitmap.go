@@ -0,0 +1,67 @@
+package uniswapV3Math
+
+import "math/big"
+
+// Source of the initialized ticks of a pool
+type TickDataProvider interface {
+	// Returns the tick bitmap word at a position, as stored by UniswapV3Pool.tickBitmap
+	TickBitmap(wordPosition int16) (*big.Int, error)
+	// Returns the net liquidity added when an initialized tick is crossed from left to right
+	LiquidityNet(tick int) (*big.Int, error)
+}
+
+// Returns the word and bit position of a compressed tick in the tick bitmap
+func TickBitmapPosition(compressedTick int) (int16, uint) {
+	return int16(compressedTick >> 8), uint(compressedTick & 0xff)
+}
+
+// Returns the next initialized tick contained in the same word (or adjacent word) as the tick
+// that is either to the left (less than or equal to) or right (greater than) of the given tick.
+// Identical to TickBitmap.nextInitializedTickWithinOneWord.
+func NextInitializedTickWithinOneWord(
+	provider TickDataProvider,
+	tick int,
+	tickSpacing int,
+	lte bool,
+) (int, bool, error) {
+	compressed := tick / tickSpacing
+	if tick < 0 && tick%tickSpacing != 0 {
+		compressed-- // round towards negative infinity
+	}
+
+	if lte {
+		wordPosition, bitPosition := TickBitmapPosition(compressed)
+		word, err := provider.TickBitmap(wordPosition)
+		if err != nil {
+			return 0, false, err
+		}
+
+		// All the 1s at or to the right of the current bitPosition
+		mask := new(big.Int).Lsh(big.NewInt(1), bitPosition+1)
+		mask.Sub(mask, big.NewInt(1))
+		masked := mask.And(mask, word)
+
+		if masked.Sign() != 0 {
+			mostSignificantBit := masked.BitLen() - 1
+			return (compressed - int(bitPosition) + mostSignificantBit) * tickSpacing, true, nil
+		}
+
+		return (compressed - int(bitPosition)) * tickSpacing, false, nil
+	}
+
+	// Start from the word of the next tick, since the current tick state doesn't matter
+	wordPosition, bitPosition := TickBitmapPosition(compressed + 1)
+	word, err := provider.TickBitmap(wordPosition)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// All the 1s at or to the left of the bitPosition
+	masked := new(big.Int).Rsh(word, bitPosition)
+	if masked.Sign() != 0 {
+		leastSignificantBit := int(masked.TrailingZeroBits())
+		return (compressed + 1 + leastSignificantBit) * tickSpacing, true, nil
+	}
+
+	return (compressed + 1 + (255 - int(bitPosition))) * tickSpacing, false, nil
+}
@@ -0,0 +1,144 @@
+package uniswapV3Math
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// Initialized ticks of a fake pool, with their net liquidity
+type fakeTickProvider struct {
+	tickSpacing  int
+	liquidityNet map[int]*big.Int
+	err          error // returned by every read if set
+}
+
+func (p *fakeTickProvider) TickBitmap(wordPosition int16) (*big.Int, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	word := new(big.Int)
+	for tick := range p.liquidityNet {
+		position, bit := TickBitmapPosition(tick / p.tickSpacing)
+		if position == wordPosition {
+			word.SetBit(word, int(bit), 1)
+		}
+	}
+
+	return word, nil
+}
+
+func (p *fakeTickProvider) LiquidityNet(tick int) (*big.Int, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	if liquidityNet, found := p.liquidityNet[tick]; found {
+		return liquidityNet, nil
+	}
+
+	return new(big.Int), nil
+}
+
+func newFakeTickProvider(tickSpacing int, ticks ...int) *fakeTickProvider {
+	provider := &fakeTickProvider{tickSpacing: tickSpacing, liquidityNet: make(map[int]*big.Int)}
+	for _, tick := range ticks {
+		provider.liquidityNet[tick] = big.NewInt(1)
+	}
+
+	return provider
+}
+
+// Vectors from the TickBitmap tests of Uniswap v3-core
+func TestNextInitializedTickWithinOneWord(t *testing.T) {
+	ticks := []int{-200, -55, -4, 70, 78, 84, 139, 240, 535}
+
+	tests := []struct {
+		name            string
+		extraTick       *int
+		tick            int
+		lte             bool
+		wantNext        int
+		wantInitialized bool
+	}{
+		{name: "next initialized tick to the right", tick: 78, lte: false, wantNext: 84, wantInitialized: true},
+		{name: "next initialized tick to the right, negative", tick: -55, lte: false, wantNext: -4, wantInitialized: true},
+		{name: "tick directly to the right", tick: 77, lte: false, wantNext: 78, wantInitialized: true},
+		{name: "tick directly to the right, negative", tick: -56, lte: false, wantNext: -55, wantInitialized: true},
+		{name: "next word boundary on the right boundary", tick: 255, lte: false, wantNext: 511, wantInitialized: false},
+		{name: "next initialized tick from the next word", tick: -257, lte: false, wantNext: -200, wantInitialized: true},
+		{name: "next initialized tick in the next word", extraTick: intPtr(340), tick: 328, lte: false, wantNext: 340, wantInitialized: true},
+		{name: "skips half a word", tick: 383, lte: false, wantNext: 511, wantInitialized: false},
+		{name: "same tick if initialized", tick: 78, lte: true, wantNext: 78, wantInitialized: true},
+		{name: "tick directly to the left", tick: 79, lte: true, wantNext: 78, wantInitialized: true},
+		{name: "does not exceed the word boundary", tick: 258, lte: true, wantNext: 256, wantInitialized: false},
+		{name: "at the word boundary", tick: 256, lte: true, wantNext: 256, wantInitialized: false},
+		{name: "word boundary less one", tick: 72, lte: true, wantNext: 70, wantInitialized: true},
+		{name: "word boundary, negative", tick: -257, lte: true, wantNext: -512, wantInitialized: false},
+		{name: "entire empty word", tick: 1023, lte: true, wantNext: 768, wantInitialized: false},
+		{name: "half empty word", tick: 900, lte: true, wantNext: 768, wantInitialized: false},
+		{name: "boundary is initialized", extraTick: intPtr(329), tick: 456, lte: true, wantNext: 329, wantInitialized: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := newFakeTickProvider(1, ticks...)
+			if test.extraTick != nil {
+				provider.liquidityNet[*test.extraTick] = big.NewInt(1)
+			}
+
+			next, initialized, err := NextInitializedTickWithinOneWord(provider, test.tick, 1, test.lte)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if next != test.wantNext || initialized != test.wantInitialized {
+				t.Errorf("got %v (initialized=%v), want %v (initialized=%v)", next, initialized, test.wantNext, test.wantInitialized)
+			}
+		})
+	}
+}
+
+// Ticks which are not a multiple of the spacing are compressed towards negative infinity
+func TestNextInitializedTickWithinOneWordSpacing(t *testing.T) {
+	provider := newFakeTickProvider(60, -120, 120)
+
+	tests := []struct {
+		tick            int
+		lte             bool
+		wantNext        int
+		wantInitialized bool
+	}{
+		{-1, true, -120, true},
+		{-61, true, -120, true},
+		{-121, true, -60 * 256, false},
+		{0, false, 120, true},
+		{-1, false, 120, true},
+		{120, false, 60 * 255, false},
+	}
+
+	for _, test := range tests {
+		next, initialized, err := NextInitializedTickWithinOneWord(provider, test.tick, 60, test.lte)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if next != test.wantNext || initialized != test.wantInitialized {
+			t.Errorf("tick %v lte=%v: got %v (initialized=%v), want %v (initialized=%v)",
+				test.tick, test.lte, next, initialized, test.wantNext, test.wantInitialized)
+		}
+	}
+}
+
+func TestNextInitializedTickWithinOneWordError(t *testing.T) {
+	provider := &fakeTickProvider{tickSpacing: 1, err: errors.New("rpc failed")}
+
+	if _, _, err := NextInitializedTickWithinOneWord(provider, 0, 1, true); !errors.Is(err, provider.err) {
+		t.Errorf("got %v, want %v", err, provider.err)
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -0,0 +1,146 @@
+package uniswapV3Math
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// Vectors from the TickMath tests of Uniswap v3-core
+
+func bigInt(s string) *big.Int {
+	value, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid integer " + s)
+	}
+
+	return value
+}
+
+func TestGetSqrtRatioAtTick(t *testing.T) {
+	tests := []struct {
+		tick int
+		want *big.Int
+	}{
+		{MinTick, MinSqrtRatio},
+		{MinTick + 1, bigInt("4295343490")},
+		{0, Q96},
+		{MaxTick - 1, bigInt("1461373636630004318706518188784493106690254656249")},
+		{MaxTick, MaxSqrtRatio},
+	}
+
+	for _, test := range tests {
+		got, err := GetSqrtRatioAtTick(test.tick)
+		if err != nil {
+			t.Fatalf("tick %v: %v", test.tick, err)
+		}
+
+		if got.Cmp(test.want) != 0 {
+			t.Errorf("tick %v: got %v, want %v", test.tick, got, test.want)
+		}
+	}
+
+	for _, tick := range []int{MinTick - 1, MaxTick + 1} {
+		if _, err := GetSqrtRatioAtTick(tick); !errors.Is(err, ErrTickOutOfRange) {
+			t.Errorf("tick %v: got %v, want %v", tick, err, ErrTickOutOfRange)
+		}
+	}
+}
+
+// The ratio is at most off by 1/100th of a bip from sqrt(1.0001^tick) * 2^96
+func TestGetSqrtRatioAtTickPrecision(t *testing.T) {
+	ticks := []int{50, 100, 250, 500, 1000, 2500, 3000, 4000, 5000, 50000, 150000, 250000, 500000, 738203}
+
+	for _, absTick := range ticks {
+		for _, tick := range []int{absTick, -absTick} {
+			got, err := GetSqrtRatioAtTick(tick)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := exactSqrtRatio(tick)
+			diff := new(big.Float).Sub(new(big.Float).SetInt(got), want)
+			diff.Quo(diff.Abs(diff), want)
+			if diff.Cmp(big.NewFloat(1e-6)) > 0 {
+				t.Errorf("tick %v: got %v, want %v (relative error %v)", tick, got, want.Text('f', 0), diff)
+			}
+		}
+	}
+}
+
+// Returns sqrt(1.0001)^tick * 2^96 with 256 bits of precision
+func exactSqrtRatio(tick int) *big.Float {
+	const precision = 256
+
+	base := new(big.Float).SetPrec(precision).SetFloat64(1.0001)
+	base.Sqrt(base)
+
+	result := new(big.Float).SetPrec(precision).SetInt64(1)
+	absTick := tick
+	if tick < 0 {
+		absTick = -tick
+	}
+	for ; absTick > 0; absTick >>= 1 {
+		if absTick&1 != 0 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+	}
+
+	if tick < 0 {
+		result.Quo(new(big.Float).SetPrec(precision).SetInt64(1), result)
+	}
+
+	return result.Mul(result, new(big.Float).SetPrec(precision).SetInt(Q96))
+}
+
+func TestGetTickAtSqrtRatio(t *testing.T) {
+	tests := []struct {
+		sqrtRatio *big.Int
+		want      int
+	}{
+		{MinSqrtRatio, MinTick},
+		{bigInt("4295343490"), MinTick + 1},
+		{Q96, 0},
+		{bigInt("1461373636630004318706518188784493106690254656249"), MaxTick - 1},
+		{new(big.Int).Sub(MaxSqrtRatio, big.NewInt(1)), MaxTick - 1},
+	}
+
+	for _, test := range tests {
+		got, err := GetTickAtSqrtRatio(test.sqrtRatio)
+		if err != nil {
+			t.Fatalf("ratio %v: %v", test.sqrtRatio, err)
+		}
+
+		if got != test.want {
+			t.Errorf("ratio %v: got %v, want %v", test.sqrtRatio, got, test.want)
+		}
+	}
+
+	for _, sqrtRatio := range []*big.Int{new(big.Int).Sub(MinSqrtRatio, big.NewInt(1)), MaxSqrtRatio} {
+		if _, err := GetTickAtSqrtRatio(sqrtRatio); !errors.Is(err, ErrSqrtRatioOutOfRange) {
+			t.Errorf("ratio %v: got %v, want %v", sqrtRatio, err, ErrSqrtRatioOutOfRange)
+		}
+	}
+}
+
+// getTickAtSqrtRatio returns the greatest tick whose ratio is at most the sqrt ratio
+func TestGetTickAtSqrtRatioRoundTrip(t *testing.T) {
+	ticks := []int{MinTick + 1, -500000, -50000, -4000, -60, -1, 1, 60, 4000, 50000, 500000, MaxTick - 1}
+
+	for _, tick := range ticks {
+		sqrtRatio, err := GetSqrtRatioAtTick(tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, err := GetTickAtSqrtRatio(sqrtRatio); err != nil || got != tick {
+			t.Errorf("ratio of tick %v: got %v (%v), want %v", tick, got, err, tick)
+		}
+
+		below := new(big.Int).Sub(sqrtRatio, big.NewInt(1))
+		if got, err := GetTickAtSqrtRatio(below); err != nil || got != tick-1 {
+			t.Errorf("ratio below tick %v: got %v (%v), want %v", tick, got, err, tick-1)
+		}
+	}
+}
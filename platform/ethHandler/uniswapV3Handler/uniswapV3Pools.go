@@ -53,22 +53,58 @@ func genPoolsMapKey(chainId ethHandler.ChainId, fee uint, base string, quote str
 	return fmt.Sprintf("%v|%v|%v/%v", chainId, fee, base, quote)
 }
 
+// Resolves the canonical base and quote assets to the pool of their tokens.
+// Native ETH resolves to WETH through the asset registry.
+// Pools missing from the registry are resolved by computing their CREATE2 address.
 func GetPool(chainId ethHandler.ChainId, fee uint, base string, quote string) (*PoolWrapper, error) {
-	// Wrap native ETH
-	if base == "ETH" {
-		base = "WETH"
+	baseToken, err := ethHandler.GetTokenForAsset(chainId, base)
+	if err != nil {
+		return nil, err
 	}
-	if quote == "ETH" {
-		quote = "WETH"
+
+	quoteToken, err := ethHandler.GetTokenForAsset(chainId, quote)
+	if err != nil {
+		return nil, err
 	}
 
-	key := genPoolsMapKey(chainId, fee, base, quote)
+	return GetPoolForTokens(chainId, fee, baseToken, quoteToken)
+}
+
+// Resolves two tokens to their pool of a fee tier, in either order
+func GetPoolForTokens(chainId ethHandler.ChainId, fee uint, baseToken *ethHandler.Token, quoteToken *ethHandler.Token) (*PoolWrapper, error) {
+	key := genPoolsMapKey(chainId, fee, baseToken.Symbol, quoteToken.Symbol)
 	pool, poolFound := poolsMap[key]
-	if !poolFound {
-		return nil, fmt.Errorf("unknown pool with chainId=%v fee=%v base=%v quote=%v", chainId, fee, base, quote)
+	if poolFound {
+		return pool, nil
+	}
+
+	key = genPoolsMapKey(chainId, fee, quoteToken.Symbol, baseToken.Symbol)
+	pool, poolFound = poolsMap[key]
+	if poolFound {
+		return pool, nil
+	}
+
+	factoryAddress, err := GetFactoryAddress(chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	token0, token1 := baseToken, quoteToken
+	token0Address, _ := ethHandler.SortTokens(baseToken.AddressForGeth(), quoteToken.AddressForGeth())
+	if token0Address != baseToken.AddressForGeth() {
+		token0, token1 = quoteToken, baseToken
+	}
+
+	poolAddress := ComputePoolAddress(factoryAddress, token0.AddressForGeth(), token1.AddressForGeth(), fee)
+	result := &PoolWrapper{
+		ChainId:      chainId,
+		PoolAddress:  poolAddress.Hex(),
+		Fee:          fee,
+		Token0Symbol: token0.Symbol,
+		Token1Symbol: token1.Symbol,
 	}
 
-	return pool, nil
+	return result, nil
 }
 
 func (p *PoolWrapper) Symbol() string {
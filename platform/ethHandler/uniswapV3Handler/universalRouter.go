@@ -0,0 +1,212 @@
+package uniswapV3Handler
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/Opulentia-Trading/Arbitrage/contracts/permit2"
+	"github.com/Opulentia-Trading/Arbitrage/contracts/uniswapUniversalRouter"
+	"github.com/Opulentia-Trading/Arbitrage/models"
+	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Commands of the Universal Router, every command is one byte of the commands of execute
+const (
+	commandV3SwapExactIn  byte = 0x00
+	commandV3SwapExactOut byte = 0x01
+	commandPermit2Permit  byte = 0x0a
+	commandWrapETH        byte = 0x0b
+	commandUnwrapWETH     byte = 0x0c
+)
+
+// Recipients resolved by the Universal Router
+var (
+	routerMsgSender   = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	routerAddressThis = common.HexToAddress("0x0000000000000000000000000000000000000002")
+)
+
+// Commands and inputs of a Universal Router execution
+type routerCommands struct {
+	commands []byte
+	inputs   [][]byte
+}
+
+func (c *routerCommands) add(command byte, arguments abi.Arguments, values ...interface{}) error {
+	input, err := arguments.Pack(values...)
+	if err != nil {
+		return err
+	}
+
+	c.commands = append(c.commands, command)
+	c.inputs = append(c.inputs, input)
+	return nil
+}
+
+func newArguments(typeNames ...string) abi.Arguments {
+	arguments := make(abi.Arguments, len(typeNames))
+	for i, typeName := range typeNames {
+		argumentType, err := abi.NewType(typeName, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		arguments[i] = abi.Argument{Type: argumentType}
+	}
+
+	return arguments
+}
+
+var (
+	// (address recipient, uint256 amount, uint256 amountLimit, bytes path, bool payerIsUser)
+	v3SwapArguments = newArguments("address", "uint256", "uint256", "bytes", "bool")
+	// (address recipient, uint256 amountMin)
+	wethArguments = newArguments("address", "uint256")
+)
+
+// Sends swaps of the same tokens through the Universal Router.
+// The router pulls the input token through Permit2: the token is approved once for Permit2
+// and the router is allowed to spend the input of the swaps by a permit batched before them.
+func (h *UniswapV3Handler) executeUniversalRouterSwaps(
+	wallet *ethHandler.Wallet,
+	swaps []*SwapParams,
+	routerAddress common.Address,
+) (*types.Transaction, error) {
+	first := swaps[0]
+	maxAmountIn := swapsMaxAmountIn(swaps)
+
+	var commands routerCommands
+	value := new(big.Int)
+	if first.NativeIn {
+		value.Set(maxAmountIn)
+		err := commands.add(commandWrapETH, wethArguments, routerAddressThis, maxAmountIn)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		permit, err := h.permit2ForSwaps(wallet, first.Tokens[0], routerAddress, maxAmountIn, first.Deadline)
+		if err != nil {
+			return nil, err
+		}
+
+		if permit != nil {
+			err := h.addPermit2Command(&commands, permit)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	amountMinimum := new(big.Int)
+	for _, params := range swaps {
+		if params.SqrtPriceLimitX96 != nil && params.SqrtPriceLimitX96.Sign() != 0 {
+			return nil, errors.New("the Universal Router does not support price limits")
+		}
+
+		recipient := params.Recipient
+		if params.NativeOut {
+			recipient = routerAddressThis
+		}
+
+		err := addV3SwapCommand(&commands, params, recipient)
+		if err != nil {
+			return nil, err
+		}
+
+		if params.SwapKind == models.ExactOutput {
+			amountMinimum.Add(amountMinimum, params.Amount)
+		} else {
+			amountMinimum.Add(amountMinimum, params.AmountLimit)
+		}
+	}
+
+	// The WETH left by exact output swaps paid with native ETH is refunded as ETH
+	if first.NativeIn && first.SwapKind == models.ExactOutput {
+		err := commands.add(commandUnwrapWETH, wethArguments, routerMsgSender, new(big.Int))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if first.NativeOut {
+		err := commands.add(commandUnwrapWETH, wethArguments, first.Recipient, amountMinimum)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	auth, err := h.TransactOpts(wallet, value, h.SendSwapTx)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := uniswapUniversalRouter.NewUniswapUniversalRouter(routerAddress, h.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	// execute(bytes commands, bytes[] inputs, uint256 deadline)
+	return instance.Execute(auth, commands.commands, commands.inputs, big.NewInt(first.Deadline.Unix()))
+}
+
+// Approves the input token for Permit2 if needed and returns the permit allowing the router
+// to spend the input of the swaps, or nil if its Permit2 allowance already covers it
+func (h *UniswapV3Handler) permit2ForSwaps(
+	wallet *ethHandler.Wallet,
+	tokenIn common.Address,
+	routerAddress common.Address,
+	amount *big.Int,
+	deadline time.Time,
+) (*ethHandler.SignedPermitSingle, error) {
+	if h.Permit2 == nil {
+		return nil, errors.New("no Permit2 allowance manager")
+	}
+
+	inputToken, err := ethHandler.GetTokenByAddress(h.Network.ChainId, tokenIn.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: Pre-approve tokens on init
+	err = h.Permit2.EnsureApproved(wallet, inputToken, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Permit2.PermitFor(wallet, inputToken, routerAddress, amount, deadline)
+}
+
+// PERMIT2_PERMIT takes the arguments of Permit2's permit without the owner, which is the sender
+func (h *UniswapV3Handler) addPermit2Command(commands *routerCommands, permit *ethHandler.SignedPermitSingle) error {
+	permit2Abi, err := permit2.Permit2MetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+
+	// permit(address owner, PermitSingle permitSingle, bytes signature)
+	arguments := permit2Abi.Methods["permit"].Inputs[1:]
+	return commands.add(commandPermit2Permit, arguments, permit.Permit, permit.Signature)
+}
+
+// The router pays the swap with the wrapped native ETH it holds, or pulls the input from the sender
+func addV3SwapCommand(commands *routerCommands, params *SwapParams, recipient common.Address) error {
+	payerIsUser := !params.NativeIn
+	if params.SwapKind == models.ExactOutput {
+		tokens, fees := reversePath(params.Tokens, params.Fees)
+		path, err := EncodePath(tokens, fees)
+		if err != nil {
+			return err
+		}
+
+		return commands.add(commandV3SwapExactOut, v3SwapArguments, recipient, params.Amount, params.AmountLimit, path, payerIsUser)
+	}
+
+	path, err := EncodePath(params.Tokens, params.Fees)
+	if err != nil {
+		return err
+	}
+
+	return commands.add(commandV3SwapExactIn, v3SwapArguments, recipient, params.Amount, params.AmountLimit, path, payerIsUser)
+}
@@ -1,8 +1,11 @@
 package ethHandler
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -55,6 +58,47 @@ func (w *Wallet) PublicKeyHex() string {
 	return hexutil.Encode(publicKeyBytes)[4:]
 }
 
+// Signs the EIP-712 hash of a struct in a signing domain.
+// Returns the 65 bytes r ++ s ++ v signature, with v set to 27 or 28 as expected by ecrecover.
+func (w *Wallet) SignTypedData(domainSeparator [32]byte, structHash [32]byte) ([]byte, error) {
+	digest := crypto.Keccak256([]byte("\x19\x01"), domainSeparator[:], structHash[:])
+	signature, err := crypto.Sign(digest, w.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
 func (w *Wallet) String() string {
 	return w.Address.Hex()
 }
+
+// Returns the options of a transaction of a wallet sending an amount of ETH.
+// The transaction is signed but not broadcast unless send is set.
+func (e *EthHandler) TransactOpts(wallet *Wallet, value *big.Int, send bool) (*bind.TransactOpts, error) {
+	chainId := big.NewInt(int64(e.Network.ChainId))
+	auth, err := bind.NewKeyedTransactorWithChainID(wallet.PrivateKey, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: Maybe keep track of nonce locally
+	nonce, err := e.Client.PendingNonceAt(context.Background(), wallet.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	auth.Nonce = big.NewInt(int64(nonce))
+	auth.Value = value
+	auth.NoSend = !send
+
+	// TODO: Get gas estimates from the gasEstimator module
+	auth.GasPrice = nil
+	auth.GasFeeCap = nil
+	auth.GasTipCap = nil
+	auth.GasLimit = uint64(0) // in units (300000 should be a good upper bound)
+
+	return auth, nil
+}
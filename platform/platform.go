@@ -6,6 +6,8 @@ import (
 
 	"github.com/Opulentia-Trading/Arbitrage/models"
 	"github.com/Opulentia-Trading/Arbitrage/platform/cexHandler/binanceHandler"
+	"github.com/Opulentia-Trading/Arbitrage/platform/cexHandler/coinbaseHandler"
+	"github.com/Opulentia-Trading/Arbitrage/platform/cexHandler/krakenHandler"
 	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler/uniswapV2Handler"
 	"github.com/Opulentia-Trading/Arbitrage/platform/ethHandler/uniswapV3Handler"
 )
@@ -26,8 +28,14 @@ func GetPlatform(platformName string) (Platform, error) {
 	switch platformName {
 	case binanceHandler.PlatformName:
 		return binanceHandler.NewBinanceHandler(), nil
-	case uniswapV2Handler.PlatformName:
-		handler, err := uniswapV2Handler.NewUniswapV2Handler()
+	case coinbaseHandler.PlatformName:
+		return coinbaseHandler.NewCoinbaseHandler(), nil
+	case krakenHandler.PlatformName:
+		return krakenHandler.NewKrakenHandler(), nil
+	case uniswapV2Handler.PlatformName,
+		uniswapV2Handler.SushiswapPlatformName,
+		uniswapV2Handler.PancakeswapPlatformName:
+		handler, err := uniswapV2Handler.NewUniswapV2ForkHandler(platformName)
 		if err != nil {
 			return nil, err
 		}